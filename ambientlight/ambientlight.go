@@ -0,0 +1,146 @@
+// Package ambientlight has control routines for the Ambient Light Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package ambientlight
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// AmbientLight is a control structure for Ambient Light Bricklets
+type AmbientLight struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+// New creates a new ambient light control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*AmbientLight, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &AmbientLight{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// GetIlluminance returns the illuminance of the sensor in lux/10.
+func (a *AmbientLight) GetIlluminance() (uint16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(a.uid, 1, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := a.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the illuminance
+	var illuminance uint16
+	if err = res.Decode(&illuminance); err != nil {
+		return 0, err
+	}
+
+	return illuminance, nil
+}
+
+// GetAnalogValue returns the raw value of the analog-digital converter (0 to 4095).
+func (a *AmbientLight) GetAnalogValue() (uint16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(a.uid, 2, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := a.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the value
+	var value uint16
+	if err = res.Decode(&value); err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+// SetIlluminanceCallbackPeriod sets the period in ms with which the illuminance callback is triggered.
+// The callback is only triggered if the illuminance changed. A value of 0 turns the callback off.
+func (a *AmbientLight) SetIlluminanceCallbackPeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(a.uid, 3, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = a.t.Send(p)
+	return err
+}
+
+// SetIlluminanceThreshold sets the threshold for the illuminance reached callback.
+// 'option' is one of the helpers.Threshold* constants.
+func (a *AmbientLight) SetIlluminanceThreshold(option byte, min, max uint16) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(a.uid, 7, false, option, min, max)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = a.t.Send(p)
+	return err
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (a *AmbientLight) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(a.t, a.uid)
+	return i, err
+}
+
+type illuminanceHandler func(uint16)
+
+func (f illuminanceHandler) Handle(p *tinkerforge.Packet) {
+
+	var illuminance uint16
+
+	if p.Decode(&illuminance) != nil {
+		return
+	}
+	f(illuminance)
+
+}
+
+// CallbackIlluminance is a convenience function for registering
+// a handler to be called periodically with the illuminance.
+// The period is set with SetIlluminanceCallbackPeriod.
+func (a *AmbientLight) CallbackIlluminance(handler func(uint16)) {
+
+	if handler == nil {
+		a.callbacks.Set(a.t, a.uid, 13, nil)
+	} else {
+		a.callbacks.Set(a.t, a.uid, 13, illuminanceHandler(handler))
+	}
+
+}
+
+// CallbackIlluminanceReached is a convenience function for registering
+// a handler to be called when the threshold set by SetIlluminanceThreshold is reached.
+func (a *AmbientLight) CallbackIlluminanceReached(handler func(uint16)) {
+
+	if handler == nil {
+		a.callbacks.Set(a.t, a.uid, 15, nil)
+	} else {
+		a.callbacks.Set(a.t, a.uid, 15, illuminanceHandler(handler))
+	}
+
+}
@@ -0,0 +1,24 @@
+package ambientlight
+
+import (
+	"testing"
+
+	"github.com/noxer/tinkerforge/helpers"
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+func TestWire(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	a, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.Expect(t, a.uid, []tinkerforgetest.Expectation{
+		{Name: "SetIlluminanceCallbackPeriod", Call: func() error { return a.SetIlluminanceCallbackPeriod(1000) }, FuncID: 3, Payload: []byte{0xe8, 0x03, 0, 0}},
+		{Name: "SetIlluminanceThreshold", Call: func() error { return a.SetIlluminanceThreshold(helpers.ThresholdInside, 200, 9000) }, FuncID: 7, Payload: []byte{'i', 0xc8, 0x00, 0x28, 0x23}},
+		{Name: "SetIlluminanceThreshold off", Call: func() error { return a.SetIlluminanceThreshold(helpers.ThresholdOff, 0, 0) }, FuncID: 7, Payload: []byte{'x', 0, 0, 0, 0}},
+	})
+}
@@ -0,0 +1,192 @@
+// Package analogin has control routines for the Analog In Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package analogin
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// AnalogIn is a control structure for Analog In Bricklets
+type AnalogIn struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+const (
+	// RangeAutomatic selects the range automatically
+	RangeAutomatic uint8 = 0
+	// Range6V measures up to 6.05V
+	Range6V uint8 = 1
+	// Range10V measures up to 10.32V
+	Range10V uint8 = 2
+	// Range36V measures up to 36.30V
+	Range36V uint8 = 3
+	// Range45V measures up to 45.00V
+	Range45V uint8 = 4
+	// Range3V measures up to 3.3V
+	Range3V uint8 = 5
+)
+
+// New creates a new analog in control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*AnalogIn, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &AnalogIn{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// GetVoltage returns the measured voltage in mV.
+func (a *AnalogIn) GetVoltage() (uint16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(a.uid, 1, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := a.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the voltage
+	var voltage uint16
+	if err = res.Decode(&voltage); err != nil {
+		return 0, err
+	}
+
+	return voltage, nil
+}
+
+// GetAnalogValue returns the raw value of the analog-digital converter (0 to 4095).
+func (a *AnalogIn) GetAnalogValue() (uint16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(a.uid, 2, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := a.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the value
+	var value uint16
+	if err = res.Decode(&value); err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+// SetVoltageCallbackPeriod sets the period in ms with which the voltage callback is triggered.
+// The callback is only triggered if the voltage changed. A value of 0 turns the callback off.
+func (a *AnalogIn) SetVoltageCallbackPeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(a.uid, 3, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = a.t.Send(p)
+	return err
+}
+
+// SetVoltageCallbackThreshold sets the threshold for the voltage reached callback.
+// 'option' is one of the helpers.Threshold* constants.
+func (a *AnalogIn) SetVoltageCallbackThreshold(option byte, min, max uint16) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(a.uid, 7, false, option, min, max)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = a.t.Send(p)
+	return err
+}
+
+// SetRange sets the measurement range (one of the Range* constants).
+func (a *AnalogIn) SetRange(r uint8) error {
+	if r > Range3V {
+		return tinkerforge.ErrInvalidParam
+	}
+
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(a.uid, 17, false, r)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = a.t.Send(p)
+	return err
+}
+
+// SetAveraging sets the number of samples averaged for the voltage measurement (0 to 255).
+// A value of 0 turns averaging off.
+func (a *AnalogIn) SetAveraging(average uint8) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(a.uid, 19, false, average)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = a.t.Send(p)
+	return err
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (a *AnalogIn) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(a.t, a.uid)
+	return i, err
+}
+
+type voltageHandler func(uint16)
+
+func (f voltageHandler) Handle(p *tinkerforge.Packet) {
+
+	var voltage uint16
+
+	if p.Decode(&voltage) != nil {
+		return
+	}
+	f(voltage)
+
+}
+
+// CallbackVoltage is a convenience function for registering
+// a handler to be called periodically with the voltage.
+// The period is set with SetVoltageCallbackPeriod.
+func (a *AnalogIn) CallbackVoltage(handler func(uint16)) {
+
+	if handler == nil {
+		a.callbacks.Set(a.t, a.uid, 13, nil)
+	} else {
+		a.callbacks.Set(a.t, a.uid, 13, voltageHandler(handler))
+	}
+
+}
+
+// CallbackVoltageReached is a convenience function for registering
+// a handler to be called when the threshold set by SetVoltageCallbackThreshold is reached.
+func (a *AnalogIn) CallbackVoltageReached(handler func(uint16)) {
+
+	if handler == nil {
+		a.callbacks.Set(a.t, a.uid, 15, nil)
+	} else {
+		a.callbacks.Set(a.t, a.uid, 15, voltageHandler(handler))
+	}
+
+}
@@ -0,0 +1,45 @@
+package analogin
+
+import (
+	"testing"
+
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+func TestWire(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	a, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every averaging length fits the bricklet's range
+	m.Expect(t, a.uid, []tinkerforgetest.Expectation{
+		{Name: "SetRange(RangeAutomatic)", Call: func() error { return a.SetRange(RangeAutomatic) }, FuncID: 17, Payload: []byte{0}},
+		{Name: "SetRange(Range3V)", Call: func() error { return a.SetRange(Range3V) }, FuncID: 17, Payload: []byte{5}},
+		{Name: "SetAveraging(0)", Call: func() error { return a.SetAveraging(0) }, FuncID: 19, Payload: []byte{0}},
+		{Name: "SetAveraging(255)", Call: func() error { return a.SetAveraging(255) }, FuncID: 19, Payload: []byte{255}},
+	})
+}
+
+func TestSetRangeInvalid(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	a, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range []uint8{Range3V + 1, 255} {
+		if err := a.SetRange(r); err != tinkerforge.ErrInvalidParam {
+			t.Errorf("SetRange(%d) error = %v, want ErrInvalidParam", r, err)
+		}
+	}
+	if len(m.Sent()) != 0 {
+		t.Errorf("sent %d packets for invalid ranges, want 0", len(m.Sent()))
+	}
+}
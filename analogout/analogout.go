@@ -0,0 +1,120 @@
+// Package analogout has control routines for the Analog Out Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package analogout
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// AnalogOut is a control structure for Analog Out Bricklets
+type AnalogOut struct {
+	t   tinkerforge.Tinkerforge
+	uid uint32
+}
+
+const (
+	// ModeAnalog outputs the voltage set by SetVoltage
+	ModeAnalog uint8 = 0
+	// Mode1k connects the output with 1kOhm to ground
+	Mode1k uint8 = 1
+	// Mode100k connects the output with 100kOhm to ground
+	Mode100k uint8 = 2
+	// Mode500k connects the output with 500kOhm to ground
+	Mode500k uint8 = 3
+)
+
+// New creates a new analog out control for the bricklet with 'uid'.
+// Like all constructors it takes the Base58 UID printed on the bricklet (as in
+// tinkerforge.Enumeration), use helpers.U32ToBase58 for a numeric UID.
+func New(t tinkerforge.Tinkerforge, uid string) (*AnalogOut, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &AnalogOut{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// SetVoltage sets the output voltage in mV (0 to 5000).
+// Setting the voltage switches the mode to ModeAnalog.
+func (a *AnalogOut) SetVoltage(voltage uint16) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(a.uid, 1, false, voltage)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = a.t.Send(p)
+	return err
+}
+
+// GetVoltage returns the voltage as set by SetVoltage.
+func (a *AnalogOut) GetVoltage() (uint16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(a.uid, 2, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := a.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the voltage
+	var voltage uint16
+	if err = res.Decode(&voltage); err != nil {
+		return 0, err
+	}
+
+	return voltage, nil
+}
+
+// SetMode sets the mode of the output (one of the Mode* constants).
+// Setting a mode other than ModeAnalog sets the voltage to 0.
+func (a *AnalogOut) SetMode(mode uint8) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(a.uid, 3, false, mode)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = a.t.Send(p)
+	return err
+}
+
+// GetMode returns the mode as set by SetMode.
+func (a *AnalogOut) GetMode() (uint8, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(a.uid, 4, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := a.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the mode
+	var mode uint8
+	if err = res.Decode(&mode); err != nil {
+		return 0, err
+	}
+
+	return mode, nil
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (a *AnalogOut) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(a.t, a.uid)
+	return i, err
+}
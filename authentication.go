@@ -0,0 +1,104 @@
+package tinkerforge
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"errors"
+)
+
+const (
+	// brickdUID is the UID the brick daemon itself listens on
+	brickdUID = 1
+
+	funcGetAuthenticationNonce = 1
+	funcAuthenticate           = 2
+)
+
+var (
+	// ErrInvalidSecret is returned by Authenticate for secrets containing non ASCII characters
+	ErrInvalidSecret = errors.New("Authentication secret must be ASCII")
+)
+
+// Authenticate authenticates the connection with the brick daemon using 'secret'.
+// After an automatic reconnect the new connection is authenticated with the same secret
+// before the connection state handler is called.
+func (t *tinkerforge) Authenticate(secret string) error {
+	// The secret is restricted to ASCII
+	for i := 0; i < len(secret); i++ {
+		if secret[i] > 127 {
+			return ErrInvalidSecret
+		}
+	}
+
+	if err := t.authenticate(secret); err != nil {
+		return err
+	}
+
+	// Remember the secret for reconnects
+	t.connMutex.Lock()
+	t.secret = &secret
+	t.connMutex.Unlock()
+
+	return nil
+}
+
+// reauthenticate authenticates a new connection if Authenticate was called before
+func (t *tinkerforge) reauthenticate() {
+	t.connMutex.RLock()
+	secret := t.secret
+	t.connMutex.RUnlock()
+
+	if secret == nil {
+		return
+	}
+
+	if err := t.authenticate(*secret); err != nil {
+		t.log().Errorf("tinkerforge: authentication after reconnect failed: %v", err)
+	}
+}
+
+// authenticate runs the authentication handshake with the brick daemon
+func (t *tinkerforge) authenticate(secret string) error {
+	// Get the nonce of the server
+	p, err := NewPacket(brickdUID, funcGetAuthenticationNonce, true)
+	if err != nil {
+		return err
+	}
+
+	res, err := t.Send(p)
+	if err != nil {
+		return err
+	}
+
+	var serverNonce [4]byte
+	if err = res.Decode(&serverNonce); err != nil {
+		return err
+	}
+
+	// Generate our own nonce
+	var clientNonce [4]byte
+	if _, err = rand.Read(clientNonce[:]); err != nil {
+		return err
+	}
+
+	// Send the digest
+	p, err = NewPacket(brickdUID, funcAuthenticate, true, clientNonce, authenticationDigest(secret, serverNonce, clientNonce))
+	if err != nil {
+		return err
+	}
+
+	_, err = t.Send(p)
+	return err
+}
+
+// authenticationDigest calculates the HMAC-SHA1 of both nonces
+func authenticationDigest(secret string, serverNonce, clientNonce [4]byte) [20]byte {
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write(serverNonce[:])
+	mac.Write(clientNonce[:])
+
+	var digest [20]byte
+	copy(digest[:], mac.Sum(nil))
+	return digest
+}
@@ -0,0 +1,150 @@
+package tinkerforge
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+const testSecret = "My Authentication Secret!"
+
+func TestAuthenticationDigest(t *testing.T) {
+	// Calculated independently with Python's hmac module
+	want := "860881ee47887bd16fe2a8f4cf80a5ed077fd886"
+
+	digest := authenticationDigest(testSecret, [4]byte{1, 2, 3, 4}, [4]byte{5, 6, 7, 8})
+	if got := hex.EncodeToString(digest[:]); got != want {
+		t.Errorf("authenticationDigest() = %s, want %s", got, want)
+	}
+}
+
+// serveAuthentication answers the authentication handshake on conn and checks the digest
+func serveAuthentication(conn io.ReadWriter, secret string) error {
+	serverNonce := [4]byte{1, 2, 3, 4}
+
+	req, err := ReadPacket(conn)
+	if err != nil {
+		return err
+	}
+	if req.UID() != brickdUID || req.FunctionID() != funcGetAuthenticationNonce {
+		return fmt.Errorf("got %v, want nonce request", req)
+	}
+
+	resp, _ := NewPacket(brickdUID, funcGetAuthenticationNonce, false, serverNonce)
+	if err := resp.Serialize(conn, req.SequenceNum()); err != nil {
+		return err
+	}
+
+	req, err = ReadPacket(conn)
+	if err != nil {
+		return err
+	}
+	if req.UID() != brickdUID || req.FunctionID() != funcAuthenticate {
+		return fmt.Errorf("got %v, want authenticate request", req)
+	}
+
+	var (
+		clientNonce [4]byte
+		digest      [20]byte
+	)
+	if err := req.Decode(&clientNonce, &digest); err != nil {
+		return err
+	}
+	if digest != authenticationDigest(secret, serverNonce, clientNonce) {
+		return fmt.Errorf("invalid digest % x", digest)
+	}
+
+	resp, _ = NewPacket(brickdUID, funcAuthenticate, false)
+	return resp.Serialize(conn, req.SequenceNum())
+}
+
+func TestAuthenticate(t *testing.T) {
+	tf, daemon := newTestClient()
+	defer daemon.Close()
+	defer tf.Close()
+
+	errc := make(chan error, 1)
+	go func() { errc <- serveAuthentication(daemon, testSecret) }()
+
+	if err := tf.Authenticate(testSecret); err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if err := <-errc; err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAuthenticateInvalidSecret(t *testing.T) {
+	tf, daemon := newTestClient()
+	defer daemon.Close()
+	defer tf.Close()
+
+	if err := tf.Authenticate("Geheimnis äöü"); err != ErrInvalidSecret {
+		t.Errorf("Authenticate() error = %v, want ErrInvalidSecret", err)
+	}
+}
+
+func TestAuthenticateAfterReconnect(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	// Both connections have to be authenticated, the first one is dropped afterwards
+	errc := make(chan error, 2)
+	go func() {
+		for i := 0; i < 2; i++ {
+			conn, err := l.Accept()
+			if err != nil {
+				errc <- err
+				return
+			}
+			defer conn.Close()
+
+			errc <- serveAuthentication(conn, testSecret)
+		}
+	}()
+
+	tf, err := New(l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tf.Close()
+
+	connected := make(chan struct{}, 1)
+	tf.SetConnectionStateHandler(func(c bool) {
+		if c {
+			connected <- struct{}{}
+		}
+	})
+	tf.SetReconnect(true, 10*time.Millisecond)
+
+	if err := tf.Authenticate(testSecret); err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if err := <-errc; err != nil {
+		t.Fatal(err)
+	}
+
+	// Drop the first connection
+	tf.(*tinkerforge).connection().Close()
+
+	select {
+	case err := <-errc:
+		if err != nil {
+			t.Errorf("authentication after reconnect: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("connection was not authenticated after reconnecting")
+	}
+
+	select {
+	case <-connected:
+	case <-time.After(5 * time.Second):
+		t.Error("state handler was not called after reconnecting")
+	}
+}
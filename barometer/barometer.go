@@ -0,0 +1,199 @@
+// Package barometer has control routines for the Barometer Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package barometer
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// Barometer is a control structure for Barometer Bricklets
+type Barometer struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+// New creates a new barometer control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*Barometer, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &Barometer{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// GetAirPressure returns the air pressure in mbar/1000.
+func (b *Barometer) GetAirPressure() (int32, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(b.uid, 1, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := b.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the air pressure
+	var pressure int32
+	if err = res.Decode(&pressure); err != nil {
+		return 0, err
+	}
+
+	return pressure, nil
+}
+
+// GetAltitude returns the relative altitude in cm.
+// The altitude is calculated from the difference to the reference air pressure.
+func (b *Barometer) GetAltitude() (int32, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(b.uid, 2, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := b.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the altitude
+	var altitude int32
+	if err = res.Decode(&altitude); err != nil {
+		return 0, err
+	}
+
+	return altitude, nil
+}
+
+// SetAirPressureCallbackPeriod sets the period in ms with which the air pressure callback is triggered.
+// The callback is only triggered if the air pressure changed. A value of 0 turns the callback off.
+func (b *Barometer) SetAirPressureCallbackPeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(b.uid, 3, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = b.t.Send(p)
+	return err
+}
+
+// SetAltitudeCallbackPeriod sets the period in ms with which the altitude callback is triggered.
+// The callback is only triggered if the altitude changed. A value of 0 turns the callback off.
+func (b *Barometer) SetAltitudeCallbackPeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(b.uid, 5, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = b.t.Send(p)
+	return err
+}
+
+// SetReferenceAirPressure sets the reference air pressure in mbar/1000 for the altitude calculation.
+// A value of 0 uses the current air pressure as reference.
+func (b *Barometer) SetReferenceAirPressure(pressure int32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(b.uid, 13, false, pressure)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = b.t.Send(p)
+	return err
+}
+
+// GetChipTemperature returns the temperature of the air pressure sensor in °C/100.
+func (b *Barometer) GetChipTemperature() (int16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(b.uid, 14, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := b.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the temperature
+	var temperature int16
+	if err = res.Decode(&temperature); err != nil {
+		return 0, err
+	}
+
+	return temperature, nil
+}
+
+// SetAveraging sets the length of the moving average for the air pressure
+// and the lengths of the averages for air pressure and temperature. A value of 0 turns averaging off.
+func (b *Barometer) SetAveraging(movingAveragePressure, averagePressure, averageTemperature uint8) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(b.uid, 20, false, movingAveragePressure, averagePressure, averageTemperature)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = b.t.Send(p)
+	return err
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (b *Barometer) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(b.t, b.uid)
+	return i, err
+}
+
+type valueHandler func(int32)
+
+func (f valueHandler) Handle(p *tinkerforge.Packet) {
+
+	var value int32
+
+	if p.Decode(&value) != nil {
+		return
+	}
+	f(value)
+
+}
+
+// CallbackAirPressure is a convenience function for registering
+// a handler to be called periodically with the air pressure.
+// The period is set with SetAirPressureCallbackPeriod.
+func (b *Barometer) CallbackAirPressure(handler func(int32)) {
+
+	if handler == nil {
+		b.callbacks.Set(b.t, b.uid, 15, nil)
+	} else {
+		b.callbacks.Set(b.t, b.uid, 15, valueHandler(handler))
+	}
+
+}
+
+// CallbackAltitude is a convenience function for registering
+// a handler to be called periodically with the altitude.
+// The period is set with SetAltitudeCallbackPeriod.
+func (b *Barometer) CallbackAltitude(handler func(int32)) {
+
+	if handler == nil {
+		b.callbacks.Set(b.t, b.uid, 16, nil)
+	} else {
+		b.callbacks.Set(b.t, b.uid, 16, valueHandler(handler))
+	}
+
+}
@@ -0,0 +1,210 @@
+// Package color has control routines for the Color Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package color
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// Color is a control structure for Color Bricklets
+type Color struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+// New creates a new color control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*Color, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &Color{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// GetColor returns the measured color as red, green, blue and clear values (0 to 65535).
+func (c *Color) GetColor() (r, g, b, cl uint16, err error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(c.uid, 1, true)
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+
+	// Send the packet
+	res, err := c.t.Send(p)
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+
+	// Decode the color
+	if err = res.Decode(&r, &g, &b, &cl); err != nil {
+		return 0, 0, 0, 0, err
+	}
+
+	return r, g, b, cl, nil
+}
+
+// SetColorCallbackPeriod sets the period in ms with which the color callback is triggered.
+// The callback is only triggered if the color changed. A value of 0 turns the callback off.
+func (c *Color) SetColorCallbackPeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(c.uid, 2, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = c.t.Send(p)
+	return err
+}
+
+// LightOn turns the LED of the bricklet on.
+func (c *Color) LightOn() error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(c.uid, 10, false)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = c.t.Send(p)
+	return err
+}
+
+// LightOff turns the LED of the bricklet off.
+func (c *Color) LightOff() error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(c.uid, 11, false)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = c.t.Send(p)
+	return err
+}
+
+// SetConfig sets the gain (0: 1x, 1: 4x, 2: 16x, 3: 60x) and the integration time
+// (0: 2.4ms, 1: 24ms, 2: 101ms, 3: 154ms, 4: 700ms) of the sensor.
+func (c *Color) SetConfig(gain, integrationTime uint8) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(c.uid, 13, false, gain, integrationTime)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = c.t.Send(p)
+	return err
+}
+
+// GetConfig returns the gain and integration time as set by SetConfig.
+func (c *Color) GetConfig() (gain, integrationTime uint8, err error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(c.uid, 14, true)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Send the packet
+	res, err := c.t.Send(p)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Decode the config
+	if err = res.Decode(&gain, &integrationTime); err != nil {
+		return 0, 0, err
+	}
+
+	return gain, integrationTime, nil
+}
+
+// GetIlluminance returns the illuminance as raw value.
+// It depends on gain and integration time, see the Tinkerforge documentation for the conversion to lux.
+func (c *Color) GetIlluminance() (uint32, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(c.uid, 15, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := c.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the illuminance
+	var illuminance uint32
+	if err = res.Decode(&illuminance); err != nil {
+		return 0, err
+	}
+
+	return illuminance, nil
+}
+
+// GetColorTemperature returns the color temperature in Kelvin.
+func (c *Color) GetColorTemperature() (uint16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(c.uid, 16, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := c.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the color temperature
+	var temperature uint16
+	if err = res.Decode(&temperature); err != nil {
+		return 0, err
+	}
+
+	return temperature, nil
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (c *Color) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(c.t, c.uid)
+	return i, err
+}
+
+type colorHandler func(uint16, uint16, uint16, uint16)
+
+func (f colorHandler) Handle(p *tinkerforge.Packet) {
+
+	var (
+		r  uint16
+		g  uint16
+		b  uint16
+		cl uint16
+	)
+
+	if p.Decode(&r, &g, &b, &cl) != nil {
+		return
+	}
+	f(r, g, b, cl)
+
+}
+
+// CallbackColor is a convenience function for registering
+// a handler to be called periodically with the color.
+// The period is set with SetColorCallbackPeriod.
+func (c *Color) CallbackColor(handler func(r, g, b, c uint16)) {
+
+	if handler == nil {
+		c.callbacks.Set(c.t, c.uid, 8, nil)
+	} else {
+		c.callbacks.Set(c.t, c.uid, 8, colorHandler(handler))
+	}
+
+}
@@ -0,0 +1,95 @@
+package color
+
+import (
+	"testing"
+	"time"
+
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+func TestWire(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	c, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.Expect(t, c.uid, []tinkerforgetest.Expectation{
+		{Name: "SetColorCallbackPeriod", Call: func() error { return c.SetColorCallbackPeriod(1000) }, FuncID: 2, Payload: []byte{0xe8, 0x03, 0, 0}},
+		{Name: "LightOn", Call: c.LightOn, FuncID: 10, Payload: []byte{}},
+		{Name: "LightOff", Call: c.LightOff, FuncID: 11, Payload: []byte{}},
+		{Name: "SetConfig", Call: func() error { return c.SetConfig(3, 4) }, FuncID: 13, Payload: []byte{3, 4}},
+	})
+}
+
+func TestGetters(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	c, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Respond(c.uid, 1, uint16(1), uint16(2), uint16(3), uint16(4)); err != nil {
+		t.Fatal(err)
+	}
+	r, g, b, cl, err := c.GetColor()
+	if err != nil || r != 1 || g != 2 || b != 3 || cl != 4 {
+		t.Errorf("GetColor() = %d, %d, %d, %d, %v, want 1, 2, 3, 4", r, g, b, cl, err)
+	}
+
+	if err := m.Respond(c.uid, 14, uint8(2), uint8(3)); err != nil {
+		t.Fatal(err)
+	}
+	gain, integrationTime, err := c.GetConfig()
+	if err != nil || gain != 2 || integrationTime != 3 {
+		t.Errorf("GetConfig() = %d, %d, %v, want 2, 3", gain, integrationTime, err)
+	}
+
+	if err := m.Respond(c.uid, 15, uint32(70000)); err != nil {
+		t.Fatal(err)
+	}
+	illuminance, err := c.GetIlluminance()
+	if err != nil || illuminance != 70000 {
+		t.Errorf("GetIlluminance() = %d, %v, want 70000", illuminance, err)
+	}
+
+	if err := m.Respond(c.uid, 16, uint16(6500)); err != nil {
+		t.Fatal(err)
+	}
+	temperature, err := c.GetColorTemperature()
+	if err != nil || temperature != 6500 {
+		t.Errorf("GetColorTemperature() = %d, %v, want 6500", temperature, err)
+	}
+}
+
+func TestCallbackColor(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	c, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(chan [4]uint16, 1)
+	c.CallbackColor(func(r, g, b, c uint16) {
+		got <- [4]uint16{r, g, b, c}
+	})
+
+	if err := m.Fire(c.uid, 8, uint16(1), uint16(2), uint16(3), uint16(4)); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case color := <-got:
+		if color != [4]uint16{1, 2, 3, 4} {
+			t.Errorf("got color %v, want [1 2 3 4]", color)
+		}
+	case <-time.After(time.Second):
+		t.Error("color callback was not called")
+	}
+}
@@ -0,0 +1,221 @@
+// Package current has control routines for the Current12 and Current25 Bricklets
+// Author: Tim Scheuermann (https://github.com/noxer)
+package current
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// Current is a control structure for Current12 and Current25 Bricklets
+type Current struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+	maxValue  int16
+}
+
+// Range is the full-scale current of a bricklet in mA.
+type Range int16
+
+const (
+	// Range12 is the range of the Current12 Bricklet (-12.5A to 12.5A)
+	Range12 Range = 12500
+	// Range25 is the range of the Current25 Bricklet (-25A to 25A)
+	Range25 Range = 25000
+)
+
+// New creates a new current control for the bricklet with 'uid' and full-scale range 'r'.
+func New(t tinkerforge.Tinkerforge, uid string, r Range) (*Current, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &Current{
+		t:        t,
+		uid:      readUID,
+		maxValue: int16(r),
+	}, nil
+}
+
+// MaxCurrent returns the full-scale current of the bricklet in mA.
+func (c *Current) MaxCurrent() int16 {
+	return c.maxValue
+}
+
+// GetCurrent returns the measured current in mA.
+func (c *Current) GetCurrent() (int16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(c.uid, 1, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := c.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the current
+	var current int16
+	if err = res.Decode(&current); err != nil {
+		return 0, err
+	}
+
+	return current, nil
+}
+
+// Calibrate calibrates the 0 value of the sensor. Make sure no current flows while calibrating.
+func (c *Current) Calibrate() error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(c.uid, 2, false)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = c.t.Send(p)
+	return err
+}
+
+// IsOverCurrent returns whether more than the full-scale current was measured since the last call.
+func (c *Current) IsOverCurrent() (bool, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(c.uid, 3, true)
+	if err != nil {
+		return false, err
+	}
+
+	// Send the packet
+	res, err := c.t.Send(p)
+	if err != nil {
+		return false, err
+	}
+
+	// Decode the over current state
+	var over bool
+	if err = res.Decode(&over); err != nil {
+		return false, err
+	}
+
+	return over, nil
+}
+
+// GetAnalogValue returns the raw value of the analog-digital converter (0 to 4095).
+func (c *Current) GetAnalogValue() (uint16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(c.uid, 4, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := c.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the value
+	var value uint16
+	if err = res.Decode(&value); err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+// SetCurrentCallbackPeriod sets the period in ms with which the current callback is triggered.
+// The callback is only triggered if the current changed. A value of 0 turns the callback off.
+func (c *Current) SetCurrentCallbackPeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(c.uid, 5, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = c.t.Send(p)
+	return err
+}
+
+// SetCurrentCallbackThreshold sets the threshold for the current reached callback.
+// 'option' is one of the helpers.Threshold* constants, 'min' and 'max' must be within the full-scale range.
+func (c *Current) SetCurrentCallbackThreshold(option byte, min, max int16) error {
+	if min < -c.maxValue || min > c.maxValue || max < -c.maxValue || max > c.maxValue {
+		return tinkerforge.ErrInvalidParam
+	}
+
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(c.uid, 9, false, option, min, max)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = c.t.Send(p)
+	return err
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (c *Current) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(c.t, c.uid)
+	return i, err
+}
+
+type currentHandler func(int16)
+
+func (f currentHandler) Handle(p *tinkerforge.Packet) {
+
+	var current int16
+
+	if p.Decode(&current) != nil {
+		return
+	}
+	f(current)
+
+}
+
+// CallbackCurrent is a convenience function for registering
+// a handler to be called periodically with the current.
+// The period is set with SetCurrentCallbackPeriod.
+func (c *Current) CallbackCurrent(handler func(int16)) {
+
+	if handler == nil {
+		c.callbacks.Set(c.t, c.uid, 15, nil)
+	} else {
+		c.callbacks.Set(c.t, c.uid, 15, currentHandler(handler))
+	}
+
+}
+
+// CallbackCurrentReached is a convenience function for registering
+// a handler to be called when the threshold set by SetCurrentCallbackThreshold is reached.
+func (c *Current) CallbackCurrentReached(handler func(int16)) {
+
+	if handler == nil {
+		c.callbacks.Set(c.t, c.uid, 17, nil)
+	} else {
+		c.callbacks.Set(c.t, c.uid, 17, currentHandler(handler))
+	}
+
+}
+
+type overCurrentHandler func()
+
+func (f overCurrentHandler) Handle(p *tinkerforge.Packet) {
+	f()
+}
+
+// CallbackOverCurrent is a convenience function for registering
+// a handler to be called when more than the full-scale current was measured.
+func (c *Current) CallbackOverCurrent(handler func()) {
+
+	if handler == nil {
+		c.callbacks.Set(c.t, c.uid, 19, nil)
+	} else {
+		c.callbacks.Set(c.t, c.uid, 19, overCurrentHandler(handler))
+	}
+
+}
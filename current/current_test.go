@@ -0,0 +1,120 @@
+package current
+
+import (
+	"testing"
+	"time"
+
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+func TestWire(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	c, err := New(m, "abc", Range12)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.Expect(t, c.uid, []tinkerforgetest.Expectation{
+		{Name: "Calibrate", Call: c.Calibrate, FuncID: 2, Payload: []byte{}},
+		{Name: "SetCurrentCallbackPeriod", Call: func() error { return c.SetCurrentCallbackPeriod(1000) }, FuncID: 5, Payload: []byte{0xe8, 0x03, 0, 0}},
+		{Name: "SetCurrentCallbackThreshold", Call: func() error { return c.SetCurrentCallbackThreshold(helpers.ThresholdOutside, -1000, 1000) }, FuncID: 9, Payload: []byte{'o', 0x18, 0xfc, 0xe8, 0x03}},
+	})
+}
+
+func TestThresholdOutOfRange(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	c, err := New(m, "abc", Range12)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.SetCurrentCallbackThreshold(helpers.ThresholdOutside, -13000, 0); err != tinkerforge.ErrInvalidParam {
+		t.Errorf("SetCurrentCallbackThreshold(-13000) = %v, want ErrInvalidParam", err)
+	}
+	if len(m.Sent()) != 0 {
+		t.Errorf("invalid threshold was sent: %v", m.Sent())
+	}
+}
+
+func TestGetCurrent(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	c, err := New(m, "abc", Range25)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Respond(c.uid, 1, int16(-2500)); err != nil {
+		t.Fatal(err)
+	}
+
+	current, err := c.GetCurrent()
+	if err != nil || current != -2500 {
+		t.Errorf("GetCurrent() = %d, %v, want -2500", current, err)
+	}
+}
+
+func TestIsOverCurrent(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	c, err := New(m, "abc", Range25)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []bool{true, false} {
+		if err := m.Respond(c.uid, 3, want); err != nil {
+			t.Fatal(err)
+		}
+
+		over, err := c.IsOverCurrent()
+		if err != nil || over != want {
+			t.Errorf("IsOverCurrent() = %t, %v, want %t", over, err, want)
+		}
+	}
+}
+
+func TestCallbacks(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	c, err := New(m, "abc", Range12)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	current := make(chan int16, 1)
+	over := make(chan struct{}, 1)
+	c.CallbackCurrent(func(value int16) { current <- value })
+	c.CallbackOverCurrent(func() { over <- struct{}{} })
+
+	if err := m.Fire(c.uid, 15, int16(-300)); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Fire(c.uid, 19); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case value := <-current:
+		if value != -300 {
+			t.Errorf("current callback got %d, want -300", value)
+		}
+	case <-time.After(time.Second):
+		t.Error("current callback was not called")
+	}
+
+	select {
+	case <-over:
+	case <-time.After(time.Second):
+		t.Error("over current callback was not called")
+	}
+}
@@ -0,0 +1,170 @@
+// Package devices creates the matching controller for a device identifier
+// Author: Tim Scheuermann (https://github.com/noxer)
+package devices
+
+import (
+	"fmt"
+
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/ambientlight"
+	"github.com/noxer/tinkerforge/analogin"
+	"github.com/noxer/tinkerforge/analogout"
+	"github.com/noxer/tinkerforge/barometer"
+	"github.com/noxer/tinkerforge/color"
+	"github.com/noxer/tinkerforge/current"
+	"github.com/noxer/tinkerforge/distanceir"
+	"github.com/noxer/tinkerforge/distanceus"
+	"github.com/noxer/tinkerforge/dualbutton"
+	"github.com/noxer/tinkerforge/dualrelay"
+	"github.com/noxer/tinkerforge/gps"
+	"github.com/noxer/tinkerforge/halleffect"
+	"github.com/noxer/tinkerforge/heartrate"
+	"github.com/noxer/tinkerforge/helpers"
+	"github.com/noxer/tinkerforge/humidity"
+	"github.com/noxer/tinkerforge/imu"
+	"github.com/noxer/tinkerforge/industrialdigitalin4"
+	"github.com/noxer/tinkerforge/industrialdigitalout4"
+	"github.com/noxer/tinkerforge/industrialdual020ma"
+	"github.com/noxer/tinkerforge/industrialquadrelay"
+	"github.com/noxer/tinkerforge/io16"
+	"github.com/noxer/tinkerforge/io4"
+	"github.com/noxer/tinkerforge/joystick"
+	"github.com/noxer/tinkerforge/lcd16x2"
+	"github.com/noxer/tinkerforge/lcd20x4"
+	"github.com/noxer/tinkerforge/ledstrip"
+	"github.com/noxer/tinkerforge/line"
+	"github.com/noxer/tinkerforge/linearpoti"
+	"github.com/noxer/tinkerforge/master"
+	"github.com/noxer/tinkerforge/moisture"
+	"github.com/noxer/tinkerforge/motiondetector"
+	"github.com/noxer/tinkerforge/multitouch"
+	"github.com/noxer/tinkerforge/nfcrfid"
+	"github.com/noxer/tinkerforge/piezobuzzer"
+	"github.com/noxer/tinkerforge/piezospeaker"
+	"github.com/noxer/tinkerforge/ptc"
+	"github.com/noxer/tinkerforge/remoteswitch"
+	"github.com/noxer/tinkerforge/rotaryencoder"
+	"github.com/noxer/tinkerforge/rotarypoti"
+	"github.com/noxer/tinkerforge/segmentdisplay4x7"
+	"github.com/noxer/tinkerforge/servo"
+	"github.com/noxer/tinkerforge/solidstaterelay"
+	"github.com/noxer/tinkerforge/soundintensity"
+	"github.com/noxer/tinkerforge/stepper"
+	"github.com/noxer/tinkerforge/temperature"
+	"github.com/noxer/tinkerforge/temperatureir"
+	"github.com/noxer/tinkerforge/voltage"
+	"github.com/noxer/tinkerforge/voltagecurrent"
+)
+
+// New creates the controller for the device with 'uid' and the identifier 'deviceID'
+// (e.g. from an enumeration). The result has to be type asserted to the controller
+// type of the device's package, e.g. *ledstrip.LedStrip for the LED Strip Bricklet.
+// 'uid' is the base58 UID like for the New functions of the device packages, enumerations
+// report it in this form as well.
+func New(t tinkerforge.Tinkerforge, uid string, deviceID uint16) (interface{}, error) {
+	name, ok := helpers.DeviceIdentifiers[deviceID]
+	if !ok {
+		return nil, fmt.Errorf("unknown device identifier %d", deviceID)
+	}
+
+	switch deviceID {
+	case 13:
+		return master.New(t, uid)
+	case 14:
+		return servo.New(t, uid)
+	case 15:
+		return stepper.New(t, uid)
+	case 16:
+		return imu.New(t, uid)
+	case 21:
+		return ambientlight.New(t, uid)
+	case 23:
+		return current.New(t, uid, current.Range12)
+	case 24:
+		return current.New(t, uid, current.Range25)
+	case 25:
+		return distanceir.New(t, uid)
+	case 26:
+		return dualrelay.New(t, uid)
+	case 27:
+		return humidity.New(t, uid)
+	case 28:
+		return io16.New(t, uid)
+	case 29:
+		return io4.New(t, uid)
+	case 210:
+		return joystick.New(t, uid)
+	case 211:
+		return lcd16x2.New(t, uid)
+	case 212:
+		return lcd20x4.New(t, uid)
+	case 213:
+		return linearpoti.New(t, uid)
+	case 214:
+		return piezobuzzer.New(t, uid)
+	case 215:
+		return rotarypoti.New(t, uid)
+	case 216:
+		return temperature.New(t, uid)
+	case 217:
+		return temperatureir.New(t, uid)
+	case 218:
+		return voltage.New(t, uid)
+	case 219:
+		return analogin.New(t, uid)
+	case 220:
+		return analogout.New(t, uid)
+	case 221:
+		return barometer.New(t, uid)
+	case 222:
+		return gps.New(t, uid)
+	case 223:
+		return industrialdigitalin4.New(t, uid)
+	case 224:
+		return industrialdigitalout4.New(t, uid)
+	case 225:
+		return industrialquadrelay.New(t, uid)
+	case 226:
+		return ptc.New(t, uid)
+	case 227:
+		return voltagecurrent.New(t, uid)
+	case 228:
+		return industrialdual020ma.New(t, uid)
+	case 229:
+		return distanceus.New(t, uid)
+	case 230:
+		return dualbutton.New(t, uid)
+	case 231:
+		return ledstrip.New(t, uid)
+	case 232:
+		return moisture.New(t, uid)
+	case 233:
+		return motiondetector.New(t, uid)
+	case 234:
+		return multitouch.New(t, uid)
+	case 235:
+		return remoteswitch.New(t, uid)
+	case 236:
+		return rotaryencoder.New(t, uid)
+	case 237:
+		return segmentdisplay4x7.New(t, uid)
+	case 238:
+		return soundintensity.New(t, uid)
+	case 240:
+		return halleffect.New(t, uid)
+	case 241:
+		return line.New(t, uid)
+	case 242:
+		return piezospeaker.New(t, uid)
+	case 243:
+		return color.New(t, uid)
+	case 244:
+		return solidstaterelay.New(t, uid)
+	case 245:
+		return heartrate.New(t, uid)
+	case 246:
+		return nfcrfid.New(t, uid)
+	}
+
+	return nil, fmt.Errorf("device %s (%d) is not supported", name, deviceID)
+}
@@ -0,0 +1,90 @@
+package devices
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+func TestNew(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	tests := []struct {
+		deviceID uint16
+		typ      string
+	}{
+		{13, "*master.Master"},
+		{14, "*servo.Servo"},
+		{15, "*stepper.Stepper"},
+		{16, "*imu.IMU"},
+		{21, "*ambientlight.AmbientLight"},
+		{23, "*current.Current"},
+		{24, "*current.Current"},
+		{25, "*distanceir.DistanceIR"},
+		{26, "*dualrelay.DualRelay"},
+		{27, "*humidity.Humidity"},
+		{28, "*io16.IO16"},
+		{29, "*io4.IO4"},
+		{210, "*joystick.Joystick"},
+		{211, "*lcd16x2.LCD16x2"},
+		{212, "*lcd20x4.LCD20x4"},
+		{213, "*linearpoti.LinearPoti"},
+		{214, "*piezobuzzer.PiezoBuzzer"},
+		{215, "*rotarypoti.RotaryPoti"},
+		{216, "*temperature.Temperature"},
+		{217, "*temperatureir.TemperatureIR"},
+		{218, "*voltage.Voltage"},
+		{219, "*analogin.AnalogIn"},
+		{220, "*analogout.AnalogOut"},
+		{221, "*barometer.Barometer"},
+		{222, "*gps.GPS"},
+		{223, "*industrialdigitalin4.IndustrialDigitalIn4"},
+		{224, "*industrialdigitalout4.IndustrialDigitalOut4"},
+		{225, "*industrialquadrelay.IndustrialQuadRelay"},
+		{226, "*ptc.PTC"},
+		{227, "*voltagecurrent.VoltageCurrent"},
+		{228, "*industrialdual020ma.IndustrialDual020mA"},
+		{229, "*distanceus.DistanceUS"},
+		{230, "*dualbutton.DualButton"},
+		{231, "*ledstrip.LedStrip"},
+		{232, "*moisture.Moisture"},
+		{233, "*motiondetector.MotionDetector"},
+		{234, "*multitouch.MultiTouch"},
+		{235, "*remoteswitch.RemoteSwitch"},
+		{236, "*rotaryencoder.RotaryEncoder"},
+		{237, "*segmentdisplay4x7.SegmentDisplay4x7"},
+		{238, "*soundintensity.SoundIntensity"},
+		{240, "*halleffect.HallEffect"},
+		{241, "*line.Line"},
+		{242, "*piezospeaker.PiezoSpeaker"},
+		{243, "*color.Color"},
+		{244, "*solidstaterelay.SolidStateRelay"},
+		{245, "*heartrate.HeartRate"},
+		{246, "*nfcrfid.NFCRFID"},
+	}
+
+	for _, test := range tests {
+		dev, err := New(m, "abc", test.deviceID)
+		if err != nil {
+			t.Errorf("New(%d) error = %v", test.deviceID, err)
+			continue
+		}
+		if typ := fmt.Sprintf("%T", dev); typ != test.typ {
+			t.Errorf("New(%d) = %s, want %s", test.deviceID, typ, test.typ)
+		}
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	// Unknown identifiers and known devices without controller
+	for _, deviceID := range []uint16{0, 1, 9999, 11, 239, 2103} {
+		if dev, err := New(m, "abc", deviceID); err == nil {
+			t.Errorf("New(%d) = %T, want an error", deviceID, dev)
+		}
+	}
+}
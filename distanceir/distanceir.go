@@ -0,0 +1,183 @@
+// Package distanceir has control routines for the Distance IR Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package distanceir
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// DistanceIR is a control structure for Distance IR Bricklets
+type DistanceIR struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+// New creates a new distance IR control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*DistanceIR, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &DistanceIR{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// GetDistance returns the distance measured by the sensor in mm.
+func (d *DistanceIR) GetDistance() (uint16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(d.uid, 1, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := d.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the distance
+	var distance uint16
+	if err = res.Decode(&distance); err != nil {
+		return 0, err
+	}
+
+	return distance, nil
+}
+
+// GetAnalogValue returns the raw value of the analog-digital converter (0 to 4095).
+func (d *DistanceIR) GetAnalogValue() (uint16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(d.uid, 2, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := d.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the value
+	var value uint16
+	if err = res.Decode(&value); err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+// SetDistanceCallbackPeriod sets the period in ms with which the distance callback is triggered.
+// The callback is only triggered if the distance changed. A value of 0 turns the callback off.
+func (d *DistanceIR) SetDistanceCallbackPeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(d.uid, 5, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = d.t.Send(p)
+	return err
+}
+
+// SetDistanceCallbackThreshold sets the threshold for the distance reached callback.
+// 'option' is one of the helpers.Threshold* constants.
+func (d *DistanceIR) SetDistanceCallbackThreshold(option byte, min, max uint16) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(d.uid, 9, false, option, min, max)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = d.t.Send(p)
+	return err
+}
+
+// SetSamplingPoint sets the distance in mm/10 for the sampling point at 'position' (0 to 127).
+// The sampling points map the analog value (position * 32) to a distance.
+func (d *DistanceIR) SetSamplingPoint(position uint8, distance uint16) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(d.uid, 3, false, position, distance)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = d.t.Send(p)
+	return err
+}
+
+// GetSamplingPoint returns the distance in mm/10 for the sampling point at 'position'.
+func (d *DistanceIR) GetSamplingPoint(position uint8) (uint16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(d.uid, 4, true, position)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := d.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the distance
+	var distance uint16
+	if err = res.Decode(&distance); err != nil {
+		return 0, err
+	}
+
+	return distance, nil
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (d *DistanceIR) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(d.t, d.uid)
+	return i, err
+}
+
+type distanceHandler func(uint16)
+
+func (f distanceHandler) Handle(p *tinkerforge.Packet) {
+
+	var distance uint16
+
+	if p.Decode(&distance) != nil {
+		return
+	}
+	f(distance)
+
+}
+
+// CallbackDistance is a convenience function for registering
+// a handler to be called periodically with the distance.
+// The period is set with SetDistanceCallbackPeriod.
+func (d *DistanceIR) CallbackDistance(handler func(uint16)) {
+
+	if handler == nil {
+		d.callbacks.Set(d.t, d.uid, 15, nil)
+	} else {
+		d.callbacks.Set(d.t, d.uid, 15, distanceHandler(handler))
+	}
+
+}
+
+// CallbackDistanceReached is a convenience function for registering
+// a handler to be called when the threshold set by SetDistanceCallbackThreshold is reached.
+func (d *DistanceIR) CallbackDistanceReached(handler func(uint16)) {
+
+	if handler == nil {
+		d.callbacks.Set(d.t, d.uid, 17, nil)
+	} else {
+		d.callbacks.Set(d.t, d.uid, 17, distanceHandler(handler))
+	}
+
+}
@@ -0,0 +1,142 @@
+// Package distanceus has control routines for the Distance US Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package distanceus
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// DistanceUS is a control structure for Distance US Bricklets
+type DistanceUS struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+// New creates a new distance US control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*DistanceUS, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &DistanceUS{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// GetDistanceValue returns the raw distance value of the sensor (0 to 4095).
+// The value is not in a unit, larger values mean larger distances.
+func (d *DistanceUS) GetDistanceValue() (uint16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(d.uid, 1, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := d.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the distance
+	var distance uint16
+	if err = res.Decode(&distance); err != nil {
+		return 0, err
+	}
+
+	return distance, nil
+}
+
+// SetDistanceCallbackPeriod sets the period in ms with which the distance callback is triggered.
+// The callback is only triggered if the distance changed. A value of 0 turns the callback off.
+func (d *DistanceUS) SetDistanceCallbackPeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(d.uid, 2, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = d.t.Send(p)
+	return err
+}
+
+// SetDistanceCallbackThreshold sets the threshold for the distance reached callback.
+// 'option' is one of the helpers.Threshold* constants.
+func (d *DistanceUS) SetDistanceCallbackThreshold(option byte, min, max uint16) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(d.uid, 4, false, option, min, max)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = d.t.Send(p)
+	return err
+}
+
+// SetMovingAverage sets the length (0 to 100) of the moving average for the distance value.
+// A value of 0 turns averaging off.
+func (d *DistanceUS) SetMovingAverage(average uint8) error {
+	if average > 100 {
+		return tinkerforge.ErrInvalidParam
+	}
+
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(d.uid, 10, false, average)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = d.t.Send(p)
+	return err
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (d *DistanceUS) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(d.t, d.uid)
+	return i, err
+}
+
+type distanceHandler func(uint16)
+
+func (f distanceHandler) Handle(p *tinkerforge.Packet) {
+
+	var distance uint16
+
+	if p.Decode(&distance) != nil {
+		return
+	}
+	f(distance)
+
+}
+
+// CallbackDistance is a convenience function for registering
+// a handler to be called periodically with the distance.
+// The period is set with SetDistanceCallbackPeriod.
+func (d *DistanceUS) CallbackDistance(handler func(uint16)) {
+
+	if handler == nil {
+		d.callbacks.Set(d.t, d.uid, 8, nil)
+	} else {
+		d.callbacks.Set(d.t, d.uid, 8, distanceHandler(handler))
+	}
+
+}
+
+// CallbackDistanceReached is a convenience function for registering
+// a handler to be called when the threshold set by SetDistanceCallbackThreshold is reached.
+func (d *DistanceUS) CallbackDistanceReached(handler func(uint16)) {
+
+	if handler == nil {
+		d.callbacks.Set(d.t, d.uid, 9, nil)
+	} else {
+		d.callbacks.Set(d.t, d.uid, 9, distanceHandler(handler))
+	}
+
+}
@@ -0,0 +1,155 @@
+// Package dualbutton has control routines for the Dual Button Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package dualbutton
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// DualButton is a control structure for Dual Button Bricklets
+type DualButton struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+const (
+	// LEDAutoToggleOn turns the LED on and toggles it with each button press
+	LEDAutoToggleOn uint8 = 0
+	// LEDAutoToggleOff turns the LED off and toggles it with each button press
+	LEDAutoToggleOff uint8 = 1
+	// LEDOn turns the LED on
+	LEDOn uint8 = 2
+	// LEDOff turns the LED off
+	LEDOff uint8 = 3
+
+	// ButtonPressed is the state of a pressed button
+	ButtonPressed uint8 = 0
+	// ButtonReleased is the state of a released button
+	ButtonReleased uint8 = 1
+
+	// LEDLeft selects the left LED
+	LEDLeft uint8 = 0
+	// LEDRight selects the right LED
+	LEDRight uint8 = 1
+)
+
+// New creates a new dual button control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*DualButton, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &DualButton{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// SetLEDState sets the state of both LEDs (one of the LED* state constants).
+func (d *DualButton) SetLEDState(ledL, ledR uint8) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(d.uid, 1, false, ledL, ledR)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = d.t.Send(p)
+	return err
+}
+
+// GetLEDState returns the current state of both LEDs.
+func (d *DualButton) GetLEDState() (ledL, ledR uint8, err error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(d.uid, 2, true)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Send the packet
+	res, err := d.t.Send(p)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Decode the LED state
+	if err = res.Decode(&ledL, &ledR); err != nil {
+		return 0, 0, err
+	}
+
+	return ledL, ledR, nil
+}
+
+// GetButtonState returns the current state of both buttons (ButtonPressed or ButtonReleased).
+func (d *DualButton) GetButtonState() (buttonL, buttonR uint8, err error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(d.uid, 3, true)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Send the packet
+	res, err := d.t.Send(p)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Decode the button state
+	if err = res.Decode(&buttonL, &buttonR); err != nil {
+		return 0, 0, err
+	}
+
+	return buttonL, buttonR, nil
+}
+
+// SetSelectedLEDState sets the state of a single LED (LEDLeft or LEDRight), leaving the other one untouched.
+func (d *DualButton) SetSelectedLEDState(led, state uint8) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(d.uid, 5, false, led, state)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = d.t.Send(p)
+	return err
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (d *DualButton) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(d.t, d.uid)
+	return i, err
+}
+
+type stateChangedHandler func(uint8, uint8, uint8, uint8)
+
+func (f stateChangedHandler) Handle(p *tinkerforge.Packet) {
+
+	var (
+		buttonL uint8
+		buttonR uint8
+		ledL    uint8
+		ledR    uint8
+	)
+
+	if p.Decode(&buttonL, &buttonR, &ledL, &ledR) != nil {
+		return
+	}
+	f(buttonL, buttonR, ledL, ledR)
+
+}
+
+// CallbackStateChanged is a convenience function for registering
+// a handler to be called when a button is pressed or released.
+func (d *DualButton) CallbackStateChanged(handler func(buttonL, buttonR, ledL, ledR uint8)) {
+
+	if handler == nil {
+		d.callbacks.Set(d.t, d.uid, 4, nil)
+	} else {
+		d.callbacks.Set(d.t, d.uid, 4, stateChangedHandler(handler))
+	}
+
+}
@@ -0,0 +1,77 @@
+package dualbutton
+
+import (
+	"testing"
+	"time"
+
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+func TestWire(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	d, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.Expect(t, d.uid, []tinkerforgetest.Expectation{
+		{Name: "SetLEDState", Call: func() error { return d.SetLEDState(LEDOn, LEDAutoToggleOff) }, FuncID: 1, Payload: []byte{2, 1}},
+		{Name: "SetSelectedLEDState", Call: func() error { return d.SetSelectedLEDState(LEDRight, LEDOff) }, FuncID: 5, Payload: []byte{1, 3}},
+	})
+}
+
+func TestGetters(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	d, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Respond(d.uid, 2, LEDOff, LEDOn); err != nil {
+		t.Fatal(err)
+	}
+	ledL, ledR, err := d.GetLEDState()
+	if err != nil || ledL != LEDOff || ledR != LEDOn {
+		t.Errorf("GetLEDState() = %d, %d, %v, want 3, 2", ledL, ledR, err)
+	}
+
+	if err := m.Respond(d.uid, 3, ButtonReleased, ButtonPressed); err != nil {
+		t.Fatal(err)
+	}
+	buttonL, buttonR, err := d.GetButtonState()
+	if err != nil || buttonL != ButtonReleased || buttonR != ButtonPressed {
+		t.Errorf("GetButtonState() = %d, %d, %v, want 1, 0", buttonL, buttonR, err)
+	}
+}
+
+func TestCallbackStateChanged(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	d, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(chan [4]uint8, 1)
+	d.CallbackStateChanged(func(buttonL, buttonR, ledL, ledR uint8) {
+		got <- [4]uint8{buttonL, buttonR, ledL, ledR}
+	})
+
+	if err := m.Fire(d.uid, 4, ButtonPressed, ButtonReleased, LEDAutoToggleOff, LEDOff); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case state := <-got:
+		if state != [4]uint8{0, 1, 1, 3} {
+			t.Errorf("got state %v, want [0 1 1 3]", state)
+		}
+	case <-time.After(time.Second):
+		t.Error("state changed callback was not called")
+	}
+}
@@ -0,0 +1,146 @@
+// Package dualrelay has control routines for the Dual Relay Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package dualrelay
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// DualRelay is a control structure for Dual Relay Bricklets
+type DualRelay struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+// New creates a new dual relay control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*DualRelay, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &DualRelay{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// SetState switches both relays on (true) or off (false).
+// A running monoflop is aborted.
+func (d *DualRelay) SetState(relay1, relay2 bool) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(d.uid, 1, false, relay1, relay2)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = d.t.Send(p)
+	return err
+}
+
+// GetState returns the current state of both relays.
+func (d *DualRelay) GetState() (relay1, relay2 bool, err error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(d.uid, 2, true)
+	if err != nil {
+		return false, false, err
+	}
+
+	// Send the packet
+	res, err := d.t.Send(p)
+	if err != nil {
+		return false, false, err
+	}
+
+	// Decode the state
+	if err = res.Decode(&relay1, &relay2); err != nil {
+		return false, false, err
+	}
+
+	return relay1, relay2, nil
+}
+
+// SetMonoflop switches 'relay' (1 or 2) to 'state' and back after 'time' ms.
+func (d *DualRelay) SetMonoflop(relay uint8, state bool, time uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(d.uid, 3, false, relay, state, time)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = d.t.Send(p)
+	return err
+}
+
+// GetMonoflop returns the state, the configured time and the remaining time in ms of a monoflop on 'relay'.
+func (d *DualRelay) GetMonoflop(relay uint8) (state bool, time, timeRemaining uint32, err error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(d.uid, 4, true, relay)
+	if err != nil {
+		return false, 0, 0, err
+	}
+
+	// Send the packet
+	res, err := d.t.Send(p)
+	if err != nil {
+		return false, 0, 0, err
+	}
+
+	// Decode the monoflop
+	if err = res.Decode(&state, &time, &timeRemaining); err != nil {
+		return false, 0, 0, err
+	}
+
+	return state, time, timeRemaining, nil
+}
+
+// SetSelectedState switches only 'relay' (1 or 2), leaving the other relay untouched.
+func (d *DualRelay) SetSelectedState(relay uint8, state bool) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(d.uid, 6, false, relay, state)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = d.t.Send(p)
+	return err
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (d *DualRelay) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(d.t, d.uid)
+	return i, err
+}
+
+type monoflopDoneHandler func(uint8, bool)
+
+func (f monoflopDoneHandler) Handle(p *tinkerforge.Packet) {
+
+	var (
+		relay uint8
+		state bool
+	)
+
+	if p.Decode(&relay, &state) != nil {
+		return
+	}
+	f(relay, state)
+
+}
+
+// CallbackMonoflopDone is a convenience function for registering
+// a handler to be called when a monoflop timer ran out.
+func (d *DualRelay) CallbackMonoflopDone(handler func(relay uint8, state bool)) {
+
+	if handler == nil {
+		d.callbacks.Set(d.t, d.uid, 5, nil)
+	} else {
+		d.callbacks.Set(d.t, d.uid, 5, monoflopDoneHandler(handler))
+	}
+
+}
@@ -0,0 +1,100 @@
+package dualrelay
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+func TestWire(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	d, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.Expect(t, d.uid, []tinkerforgetest.Expectation{
+		{Name: "SetState", Call: func() error { return d.SetState(true, false) }, FuncID: 1, Payload: []byte{1, 0}},
+		{Name: "SetMonoflop", Call: func() error { return d.SetMonoflop(2, true, 1500) }, FuncID: 3, Payload: []byte{2, 1, 0xdc, 0x05, 0, 0}},
+		{Name: "SetSelectedState", Call: func() error { return d.SetSelectedState(2, true) }, FuncID: 6, Payload: []byte{2, 1}},
+	})
+}
+
+func TestGetState(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	d, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Respond(d.uid, 2, false, true); err != nil {
+		t.Fatal(err)
+	}
+
+	relay1, relay2, err := d.GetState()
+	if err != nil || relay1 || !relay2 {
+		t.Errorf("GetState() = %t, %t, %v, want false, true", relay1, relay2, err)
+	}
+}
+
+func TestGetMonoflop(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	d, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Respond(d.uid, 4, true, uint32(1500), uint32(700)); err != nil {
+		t.Fatal(err)
+	}
+
+	state, total, remaining, err := d.GetMonoflop(1)
+	if err != nil || !state || total != 1500 || remaining != 700 {
+		t.Errorf("GetMonoflop(1) = %t, %d, %d, %v, want true, 1500, 700", state, total, remaining, err)
+	}
+
+	sent := m.Sent()
+	if len(sent) != 1 || sent[0].FunctionID() != 4 || !bytes.Equal(sent[0].Payload(), []byte{1}) {
+		t.Errorf("sent %v, want function ID 4 and payload 01", sent)
+	}
+}
+
+func TestCallbackMonoflopDone(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	d, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type done struct {
+		relay uint8
+		state bool
+	}
+	got := make(chan done, 1)
+	d.CallbackMonoflopDone(func(relay uint8, state bool) {
+		got <- done{relay, state}
+	})
+
+	if err := m.Fire(d.uid, 5, uint8(2), false); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case m := <-got:
+		if m != (done{2, false}) {
+			t.Errorf("got monoflop done %+v, want {2 false}", m)
+		}
+	case <-time.After(time.Second):
+		t.Error("monoflop done callback was not called")
+	}
+}
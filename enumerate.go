@@ -0,0 +1,117 @@
+package tinkerforge
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	funcEnumerate         = 254
+	funcCallbackEnumerate = 253
+)
+
+// Version represents a hardware or firmware version number
+type Version [3]byte
+
+// String prints a version array
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v[0], v[1], v[2])
+}
+
+// Compare returns -1 if v is older than 'other', 1 if it is newer and 0 if both are equal
+func (v Version) Compare(other Version) int {
+	for i := range v {
+		if v[i] < other[i] {
+			return -1
+		}
+		if v[i] > other[i] {
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// AtLeast reports whether v is equal to or newer than 'other'
+func (v Version) AtLeast(other Version) bool {
+	return v.Compare(other) >= 0
+}
+
+// EnumerationType describes why an enumerate callback was sent.
+type EnumerationType uint8
+
+const (
+	// EnumerationAvailable is sent as an answer to Enumerate
+	EnumerationAvailable EnumerationType = 0
+	// EnumerationConnected is sent when a device was (re)connected
+	EnumerationConnected EnumerationType = 1
+	// EnumerationDisconnected is sent when a device was disconnected (USB only)
+	EnumerationDisconnected EnumerationType = 2
+)
+
+// Enumeration holds the information a device sends in the enumerate callback.
+type Enumeration struct {
+	UID              string          `json:"uid"`
+	ConnectedUID     string          `json:"connectedUID"`
+	Position         byte            `json:"position"`
+	HardwareVersion  Version         `json:"hardwareVersion"`
+	FirmwareVersion  Version         `json:"firmwareVersion"`
+	DeviceIdentifier uint16          `json:"deviceIdentifier"`
+	EnumerationType  EnumerationType `json:"enumerationType"`
+}
+
+// Enumerate asks all connected devices to identify themselves.
+// The answers arrive through the handler registered with EnumerateCallback.
+func (t *tinkerforge) Enumerate() error {
+	// Enumerate is broadcast to UID 0
+	p, err := NewPacket(0, funcEnumerate, false)
+	if err != nil {
+		return err
+	}
+
+	return t.Broadcast(p)
+}
+
+// EnumerateCallback registers a handler to be called for every device answering
+// an enumeration or (dis)connecting. It replaces the previous handler, passing nil removes it.
+// Handlers registered with Handler or HandlerAny for function ID 253 are not affected.
+func (t *tinkerforge) EnumerateCallback(handler func(e Enumeration)) {
+	t.enumerateMutex.Lock()
+	defer t.enumerateMutex.Unlock()
+
+	// Only remove our own handler, the wildcard UID may have others
+	if t.enumerateToken != (HandlerToken{}) {
+		t.RemoveHandler(t.enumerateToken)
+		t.enumerateToken = HandlerToken{}
+	}
+
+	if handler != nil {
+		t.enumerateToken = t.Handler(0, funcCallbackEnumerate, EnumerateHandler(handler))
+	}
+}
+
+// EnumerateHandler decodes enumerate callbacks, it can be registered with Handler
+// for UID 0 and function ID 253 to get a removable enumerate handler.
+type EnumerateHandler func(Enumeration)
+
+// Handle decodes the enumeration and calls f
+func (f EnumerateHandler) Handle(p *Packet) {
+	var (
+		e                   Enumeration
+		displayUID          [8]byte
+		connectedDisplayUID [8]byte
+	)
+
+	if p.Decode(&displayUID, &connectedDisplayUID, &e.Position, &e.HardwareVersion, &e.FirmwareVersion, &e.DeviceIdentifier, &e.EnumerationType) != nil {
+		return
+	}
+
+	e.UID = trimUID(displayUID)
+	e.ConnectedUID = trimUID(connectedDisplayUID)
+	f(e)
+}
+
+// trimUID converts a null padded UID array into a string.
+func trimUID(uid [8]byte) string {
+	return strings.TrimSpace(strings.TrimRight(string(uid[:]), "\x00"))
+}
@@ -0,0 +1,97 @@
+package tinkerforge
+
+import (
+	"testing"
+	"time"
+)
+
+// enumerateFunc adapts a function to the Handler interface
+type enumerateFunc func(p *Packet)
+
+func (f enumerateFunc) Handle(p *Packet) { f(p) }
+
+func TestEnumerateCallback(t *testing.T) {
+	tf, daemon := newTestClient()
+	defer daemon.Close()
+	defer tf.Close()
+
+	// A handler registered by someone else must survive EnumerateCallback(nil)
+	other := make(chan struct{}, 2)
+	tf.HandlerAny(funcCallbackEnumerate, enumerateFunc(func(p *Packet) { other <- struct{}{} }))
+
+	got := make(chan Enumeration, 2)
+	tf.EnumerateCallback(func(e Enumeration) { got <- e })
+
+	fire := func() {
+		uid := [8]byte{'a', 'b', 'c'}
+		connected := [8]byte{'6', 'q', 'R'}
+		p, err := NewPacket(30867, funcCallbackEnumerate, false, uid, connected, byte('c'), Version{1, 1, 0}, Version{2, 0, 5}, uint16(21), EnumerationConnected)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := p.Serialize(daemon, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fire()
+
+	select {
+	case e := <-got:
+		want := Enumeration{"abc", "6qR", 'c', Version{1, 1, 0}, Version{2, 0, 5}, 21, EnumerationConnected}
+		if e != want {
+			t.Errorf("got enumeration %+v, want %+v", e, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("enumerate callback was not called")
+	}
+
+	select {
+	case <-other:
+	case <-time.After(time.Second):
+		t.Fatal("other handler was not called")
+	}
+
+	// Removing the enumerate callback keeps the other handler
+	tf.EnumerateCallback(nil)
+	fire()
+
+	select {
+	case <-other:
+	case <-time.After(time.Second):
+		t.Fatal("other handler was removed by EnumerateCallback(nil)")
+	}
+
+	select {
+	case e := <-got:
+		t.Errorf("removed enumerate callback was called with %+v", e)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	tests := []struct {
+		a, b Version
+		want int
+	}{
+		{Version{2, 0, 5}, Version{2, 0, 5}, 0},
+		{Version{2, 0, 4}, Version{2, 0, 5}, -1},
+		{Version{2, 0, 6}, Version{2, 0, 5}, 1},
+		{Version{2, 1, 0}, Version{2, 0, 9}, 1},
+		{Version{1, 9, 9}, Version{2, 0, 0}, -1},
+		{Version{3, 0, 0}, Version{2, 255, 255}, 1},
+		{Version{0, 0, 0}, Version{0, 0, 1}, -1},
+	}
+
+	for _, test := range tests {
+		if got := test.a.Compare(test.b); got != test.want {
+			t.Errorf("%v.Compare(%v) = %d, want %d", test.a, test.b, got, test.want)
+		}
+		if got := test.b.Compare(test.a); got != -test.want {
+			t.Errorf("%v.Compare(%v) = %d, want %d", test.b, test.a, got, -test.want)
+		}
+		if got := test.a.AtLeast(test.b); got != (test.want >= 0) {
+			t.Errorf("%v.AtLeast(%v) = %t, want %t", test.a, test.b, got, test.want >= 0)
+		}
+	}
+}
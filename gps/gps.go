@@ -0,0 +1,193 @@
+// Package gps has control routines for the GPS Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package gps
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// GPS is a control structure for GPS Bricklets
+type GPS struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+// Coordinates holds a position fix as reported by the GPS Bricklet.
+// The field order matches the order on the wire.
+type Coordinates struct {
+	// Latitude in DD.dddddd° * 1000000
+	Latitude uint32
+	// NS is either 'N' (north) or 'S' (south)
+	NS byte
+	// Longitude in DD.dddddd° * 1000000
+	Longitude uint32
+	// EW is either 'E' (east) or 'W' (west)
+	EW byte
+	// PDOP is the position dilution of precision * 100
+	PDOP uint16
+	// HDOP is the horizontal dilution of precision * 100
+	HDOP uint16
+	// VDOP is the vertical dilution of precision * 100
+	VDOP uint16
+	// EPE is the estimated position error in cm
+	EPE uint16
+}
+
+const (
+	// FixNone indicates that there is no fix
+	FixNone uint8 = 1
+	// Fix2D indicates a 2D fix (no altitude)
+	Fix2D uint8 = 2
+	// Fix3D indicates a 3D fix
+	Fix3D uint8 = 3
+)
+
+// New creates a new GPS control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*GPS, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &GPS{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// GetCoordinates returns the current coordinates.
+func (g *GPS) GetCoordinates() (Coordinates, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(g.uid, 1, true)
+	if err != nil {
+		return Coordinates{}, err
+	}
+
+	// Send the packet
+	res, err := g.t.Send(p)
+	if err != nil {
+		return Coordinates{}, err
+	}
+
+	// Decode the coordinates
+	var c Coordinates
+	if err = res.Decode(&c); err != nil {
+		return Coordinates{}, err
+	}
+
+	return c, nil
+}
+
+// GetStatus returns the kind of fix (one of the Fix* constants) and the number of satellites in view and in use.
+func (g *GPS) GetStatus() (fix, satellitesView, satellitesUsed uint8, err error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(g.uid, 2, true)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	// Send the packet
+	res, err := g.t.Send(p)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	// Decode the status
+	if err = res.Decode(&fix, &satellitesView, &satellitesUsed); err != nil {
+		return 0, 0, 0, err
+	}
+
+	return fix, satellitesView, satellitesUsed, nil
+}
+
+// GetAltitude returns the altitude and the geoidal separation in cm.
+func (g *GPS) GetAltitude() (altitude, geoidalSeparation int32, err error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(g.uid, 3, true)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Send the packet
+	res, err := g.t.Send(p)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Decode the altitude
+	if err = res.Decode(&altitude, &geoidalSeparation); err != nil {
+		return 0, 0, err
+	}
+
+	return altitude, geoidalSeparation, nil
+}
+
+// GetDateTime returns the current date (ddmmyy) and time (hhmmss|sss).
+func (g *GPS) GetDateTime() (date, time uint32, err error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(g.uid, 5, true)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Send the packet
+	res, err := g.t.Send(p)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Decode the date and time
+	if err = res.Decode(&date, &time); err != nil {
+		return 0, 0, err
+	}
+
+	return date, time, nil
+}
+
+// SetCoordinatesCallbackPeriod sets the period in ms with which the coordinates callback is triggered.
+// The callback is only triggered if the coordinates changed. A value of 0 turns the callback off.
+func (g *GPS) SetCoordinatesCallbackPeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(g.uid, 7, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = g.t.Send(p)
+	return err
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (g *GPS) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(g.t, g.uid)
+	return i, err
+}
+
+type coordinatesHandler func(Coordinates)
+
+func (f coordinatesHandler) Handle(p *tinkerforge.Packet) {
+
+	var c Coordinates
+
+	if p.Decode(&c) != nil {
+		return
+	}
+	f(c)
+
+}
+
+// CallbackCoordinates is a convenience function for registering
+// a handler to be called periodically with the coordinates.
+// The period is set with SetCoordinatesCallbackPeriod.
+func (g *GPS) CallbackCoordinates(handler func(Coordinates)) {
+
+	if handler == nil {
+		g.callbacks.Set(g.t, g.uid, 17, nil)
+	} else {
+		g.callbacks.Set(g.t, g.uid, 17, coordinatesHandler(handler))
+	}
+
+}
@@ -0,0 +1,72 @@
+package gps
+
+import (
+	"testing"
+	"time"
+
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+// coordinates is 52.520008 N, 13.404954 E with PDOP 1.5, HDOP 0.9, VDOP 1.2 and EPE 3.5m on the wire
+var (
+	coordinates = [18]byte{
+		0x48, 0x64, 0x21, 0x03, 'N',
+		0x1a, 0x8b, 0xcc, 0x00, 'E',
+		0x96, 0x00, 0x5a, 0x00, 0x78, 0x00, 0x5e, 0x01,
+	}
+	wantCoordinates = Coordinates{
+		Latitude:  52520008,
+		NS:        'N',
+		Longitude: 13404954,
+		EW:        'E',
+		PDOP:      150,
+		HDOP:      90,
+		VDOP:      120,
+		EPE:       350,
+	}
+)
+
+func TestGetCoordinates(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	g, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Respond(g.uid, 1, coordinates); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := g.GetCoordinates()
+	if err != nil || c != wantCoordinates {
+		t.Errorf("GetCoordinates() = %+v, %v, want %+v", c, err, wantCoordinates)
+	}
+}
+
+func TestCallbackCoordinates(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	g, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(chan Coordinates, 1)
+	g.CallbackCoordinates(func(c Coordinates) { got <- c })
+
+	if err := m.Fire(g.uid, 17, coordinates); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case c := <-got:
+		if c != wantCoordinates {
+			t.Errorf("got coordinates %+v, want %+v", c, wantCoordinates)
+		}
+	case <-time.After(time.Second):
+		t.Error("coordinates callback was not called")
+	}
+}
@@ -0,0 +1,160 @@
+// Package halleffect has control routines for the Hall Effect Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package halleffect
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// HallEffect is a control structure for Hall Effect Bricklets
+type HallEffect struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+const (
+	// EdgeRising counts rising edges
+	EdgeRising uint8 = 0
+	// EdgeFalling counts falling edges
+	EdgeFalling uint8 = 1
+	// EdgeBoth counts rising and falling edges
+	EdgeBoth uint8 = 2
+)
+
+// New creates a new hall effect control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*HallEffect, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &HallEffect{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// GetValue returns true if a magnetic field of 3.5 millitesla or greater is detected.
+func (h *HallEffect) GetValue() (bool, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(h.uid, 1, true)
+	if err != nil {
+		return false, err
+	}
+
+	// Send the packet
+	res, err := h.t.Send(p)
+	if err != nil {
+		return false, err
+	}
+
+	// Decode the value
+	var value bool
+	if err = res.Decode(&value); err != nil {
+		return false, err
+	}
+
+	return value, nil
+}
+
+// GetEdgeCount returns the current edge count.
+// If 'resetCounter' is true the count is set to 0 after reading it.
+func (h *HallEffect) GetEdgeCount(resetCounter bool) (uint32, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(h.uid, 2, true, resetCounter)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := h.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the edge count
+	var count uint32
+	if err = res.Decode(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// SetEdgeCountConfig sets the edge type (one of the Edge* constants) and the debounce time in ms.
+// The edge counter is reset when the configuration changes.
+func (h *HallEffect) SetEdgeCountConfig(edgeType, debounce uint8) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(h.uid, 3, false, edgeType, debounce)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = h.t.Send(p)
+	return err
+}
+
+// SetEdgeInterrupt triggers the edge count callback every 'edges' edges. A value of 0 turns the interrupt off.
+func (h *HallEffect) SetEdgeInterrupt(edges uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(h.uid, 5, false, edges)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = h.t.Send(p)
+	return err
+}
+
+// SetEdgeCountCallbackPeriod sets the period in ms with which the edge count callback is triggered.
+// The callback is only triggered if the count changed. A value of 0 turns the callback off.
+func (h *HallEffect) SetEdgeCountCallbackPeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(h.uid, 7, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = h.t.Send(p)
+	return err
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (h *HallEffect) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(h.t, h.uid)
+	return i, err
+}
+
+type edgeCountHandler func(uint32, bool)
+
+func (f edgeCountHandler) Handle(p *tinkerforge.Packet) {
+
+	var (
+		count uint32
+		value bool
+	)
+
+	if p.Decode(&count, &value) != nil {
+		return
+	}
+	f(count, value)
+
+}
+
+// CallbackEdgeCount is a convenience function for registering
+// a handler to be called with the edge count and the current value.
+// It is triggered periodically (SetEdgeCountCallbackPeriod) and by the edge interrupt (SetEdgeInterrupt).
+func (h *HallEffect) CallbackEdgeCount(handler func(count uint32, value bool)) {
+
+	if handler == nil {
+		h.callbacks.Set(h.t, h.uid, 10, nil)
+	} else {
+		h.callbacks.Set(h.t, h.uid, 10, edgeCountHandler(handler))
+	}
+
+}
@@ -0,0 +1,110 @@
+package halleffect
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+func TestWire(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	h, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.Expect(t, h.uid, []tinkerforgetest.Expectation{
+		{Name: "SetEdgeCountConfig", Call: func() error { return h.SetEdgeCountConfig(EdgeBoth, 100) }, FuncID: 3, Payload: []byte{2, 100}},
+		{Name: "SetEdgeInterrupt", Call: func() error { return h.SetEdgeInterrupt(70000) }, FuncID: 5, Payload: []byte{0x70, 0x11, 0x01, 0}},
+		{Name: "SetEdgeCountCallbackPeriod", Call: func() error { return h.SetEdgeCountCallbackPeriod(1000) }, FuncID: 7, Payload: []byte{0xe8, 0x03, 0, 0}},
+	})
+}
+
+func TestGetValue(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	h, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Respond(h.uid, 1, true); err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := h.GetValue()
+	if err != nil || !value {
+		t.Errorf("GetValue() = %t, %v, want true", value, err)
+	}
+}
+
+func TestGetEdgeCount(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	h, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		resetCounter bool
+		payload      []byte
+	}{
+		{false, []byte{0}},
+		{true, []byte{1}},
+	}
+
+	for i, test := range tests {
+		if err := m.Respond(h.uid, 2, uint32(70000)); err != nil {
+			t.Fatal(err)
+		}
+
+		count, err := h.GetEdgeCount(test.resetCounter)
+		if err != nil || count != 70000 {
+			t.Errorf("GetEdgeCount(%t) = %d, %v, want 70000", test.resetCounter, count, err)
+		}
+
+		p := m.Sent()[i]
+		if p.FunctionID() != 2 || !bytes.Equal(p.Payload(), test.payload) {
+			t.Errorf("GetEdgeCount(%t) sent %v, want function ID 2 and payload % x", test.resetCounter, p, test.payload)
+		}
+	}
+}
+
+func TestCallbackEdgeCount(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	h, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type edge struct {
+		count uint32
+		value bool
+	}
+	got := make(chan edge, 1)
+	h.CallbackEdgeCount(func(count uint32, value bool) {
+		got <- edge{count, value}
+	})
+
+	if err := m.Fire(h.uid, 10, uint32(70000), true); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case e := <-got:
+		if e != (edge{70000, true}) {
+			t.Errorf("got edge count %+v, want {70000 true}", e)
+		}
+	case <-time.After(time.Second):
+		t.Error("edge count callback was not called")
+	}
+}
@@ -0,0 +1,175 @@
+// Package heartrate has control routines for the Heart Rate Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package heartrate
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// HeartRate is a control structure for Heart Rate Bricklets
+type HeartRate struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+const (
+	// BeatStateFalling is sent when the beat signal falls
+	BeatStateFalling uint8 = 0
+	// BeatStateRising is sent when the beat signal rises
+	BeatStateRising uint8 = 1
+)
+
+// New creates a new heart rate control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*HeartRate, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &HeartRate{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// GetHeartRate returns the heart rate in beats per minute.
+func (h *HeartRate) GetHeartRate() (uint16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(h.uid, 1, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := h.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the heart rate
+	var heartRate uint16
+	if err = res.Decode(&heartRate); err != nil {
+		return 0, err
+	}
+
+	return heartRate, nil
+}
+
+// SetHeartRateCallbackPeriod sets the period in ms with which the heart rate callback is triggered.
+// The callback is only triggered if the heart rate changed. A value of 0 turns the callback off.
+func (h *HeartRate) SetHeartRateCallbackPeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(h.uid, 2, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = h.t.Send(p)
+	return err
+}
+
+// SetHeartRateCallbackThreshold sets the threshold for the heart rate reached callback.
+// 'option' is one of the helpers.Threshold* constants.
+func (h *HeartRate) SetHeartRateCallbackThreshold(option byte, min, max uint16) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(h.uid, 4, false, option, min, max)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = h.t.Send(p)
+	return err
+}
+
+// SetBeatStateCallbackConfiguration enables or disables the beat state changed callback.
+func (h *HeartRate) SetBeatStateCallbackConfiguration(enabled bool) error {
+	// Enable and disable are separate functions
+	funcID := uint8(12)
+	if enabled {
+		funcID = 11
+	}
+
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(h.uid, funcID, false)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = h.t.Send(p)
+	return err
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (h *HeartRate) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(h.t, h.uid)
+	return i, err
+}
+
+type heartRateHandler func(uint16)
+
+func (f heartRateHandler) Handle(p *tinkerforge.Packet) {
+
+	var heartRate uint16
+
+	if p.Decode(&heartRate) != nil {
+		return
+	}
+	f(heartRate)
+
+}
+
+// CallbackHeartRate is a convenience function for registering
+// a handler to be called periodically with the heart rate.
+// The period is set with SetHeartRateCallbackPeriod.
+func (h *HeartRate) CallbackHeartRate(handler func(uint16)) {
+
+	if handler == nil {
+		h.callbacks.Set(h.t, h.uid, 8, nil)
+	} else {
+		h.callbacks.Set(h.t, h.uid, 8, heartRateHandler(handler))
+	}
+
+}
+
+// CallbackHeartRateReached is a convenience function for registering
+// a handler to be called when the threshold set by SetHeartRateCallbackThreshold is reached.
+func (h *HeartRate) CallbackHeartRateReached(handler func(uint16)) {
+
+	if handler == nil {
+		h.callbacks.Set(h.t, h.uid, 9, nil)
+	} else {
+		h.callbacks.Set(h.t, h.uid, 9, heartRateHandler(handler))
+	}
+
+}
+
+type beatStateHandler func(uint8)
+
+func (f beatStateHandler) Handle(p *tinkerforge.Packet) {
+
+	var state uint8
+
+	if p.Decode(&state) != nil {
+		return
+	}
+	f(state)
+
+}
+
+// CallbackBeatStateChanged is a convenience function for registering
+// a handler to be called when the beat state changes (BeatStateFalling or BeatStateRising).
+// The callback is enabled with SetBeatStateCallbackConfiguration.
+func (h *HeartRate) CallbackBeatStateChanged(handler func(state uint8)) {
+
+	if handler == nil {
+		h.callbacks.Set(h.t, h.uid, 10, nil)
+	} else {
+		h.callbacks.Set(h.t, h.uid, 10, beatStateHandler(handler))
+	}
+
+}
@@ -4,6 +4,7 @@ package helpers
 // https://github.com/Tinkerforge/go-api-bindings/blob/master/internal/base58.go
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"strings"
@@ -11,6 +12,15 @@ import (
 
 const alphabet = "123456789abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ"
 
+var (
+	// ErrBase58Empty is returned for empty UIDs or UIDs mapping to zero
+	ErrBase58Empty = errors.New("UID is empty")
+	// ErrBase58InvalidChar is returned for UIDs containing characters outside the Base58 alphabet
+	ErrBase58InvalidChar = errors.New("UID contains an invalid character")
+	// ErrBase58Overflow is returned for UIDs too big to fit into an uint64
+	ErrBase58Overflow = errors.New("UID is too big to fit into an uint64")
+)
+
 func pow(base, exp uint64) uint64 {
 	result := uint64(1)
 	for i := uint64(0); i < exp; i++ {
@@ -19,9 +29,11 @@ func pow(base, exp uint64) uint64 {
 	return result
 }
 
+// Base58ToU32 converts a Base58 UID into its numeric value. The returned errors wrap
+// ErrBase58Empty, ErrBase58InvalidChar or ErrBase58Overflow.
 func Base58ToU32(str string) (uint32, error) {
 	if len(str) == 0 {
-		return 0, fmt.Errorf("UID is empty")
+		return 0, ErrBase58Empty
 	}
 
 	var result_u64 uint64
@@ -32,7 +44,7 @@ func Base58ToU32(str string) (uint32, error) {
 		r := rune(str[len(str)-idx-1])
 		i := strings.IndexRune(alphabet, r)
 		if i == -1 {
-			return uint32(result_u64), fmt.Errorf("UID %s contains an invalid character", str)
+			return uint32(result_u64), fmt.Errorf("%w: %q in %s", ErrBase58InvalidChar, r, str)
 		}
 
 		pow_overflows := digit > 0 && pow(radix, digit-1) > (math.MaxUint64/radix)
@@ -40,7 +52,7 @@ func Base58ToU32(str string) (uint32, error) {
 		add_overflows := math.MaxUint64-pow(radix, digit)*uint64(i) < result_u64
 
 		if pow_overflows || mult_overflows || add_overflows {
-			return uint32(result_u64), fmt.Errorf("UID %s is too big to fit into an uint64", str)
+			return uint32(result_u64), fmt.Errorf("%w: %s", ErrBase58Overflow, str)
 		}
 		result_u64 += pow(radix, digit) * uint64(i)
 		digit++
@@ -60,8 +72,22 @@ func Base58ToU32(str string) (uint32, error) {
 	}
 
 	if result == 0 {
-		return result, fmt.Errorf("UID is empty or mapped to zero")
+		return result, fmt.Errorf("%w: %s is mapped to zero", ErrBase58Empty, str)
 	}
 
 	return result, nil
 }
+
+// U32ToBase58 converts a numeric UID into the Base58 string printed on the devices.
+// UID 0 (the broadcast UID) is encoded as "1", which Base58ToU32 rejects as no device has it.
+func U32ToBase58(uid uint32) string {
+	radix := uint32(len(alphabet))
+
+	encoded := ""
+	for uid >= radix {
+		encoded = string(alphabet[uid%radix]) + encoded
+		uid /= radix
+	}
+
+	return string(alphabet[uid]) + encoded
+}
@@ -0,0 +1,60 @@
+package helpers
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestU32ToBase58RoundTrip(t *testing.T) {
+	tests := []struct {
+		uid uint32
+		str string
+	}{
+		{1, "2"},
+		{57, "Z"},
+		{58, "21"},
+		{30867, "abc"},
+		{math.MaxUint32 - 1, "7xwQ9f"},
+		{math.MaxUint32, "7xwQ9g"},
+	}
+
+	for _, test := range tests {
+		str := U32ToBase58(test.uid)
+		if str != test.str {
+			t.Errorf("U32ToBase58(%d) = %q, want %q", test.uid, str, test.str)
+		}
+
+		uid, err := Base58ToU32(str)
+		if err != nil || uid != test.uid {
+			t.Errorf("Base58ToU32(%q) = %d, %v, want %d", str, uid, err, test.uid)
+		}
+	}
+}
+
+func TestU32ToBase58Zero(t *testing.T) {
+	if str := U32ToBase58(0); str != "1" {
+		t.Errorf("U32ToBase58(0) = %q, want \"1\"", str)
+	}
+}
+
+func TestBase58ToU32Errors(t *testing.T) {
+	tests := []struct {
+		str string
+		err error
+	}{
+		{"", ErrBase58Empty},
+		{"1", ErrBase58Empty},
+		{"111", ErrBase58Empty},
+		{"ab0c", ErrBase58InvalidChar},
+		{"abIc", ErrBase58InvalidChar},
+		{"a-c", ErrBase58InvalidChar},
+		{"zzzzzzzzzzzzzzzzzz", ErrBase58Overflow},
+	}
+
+	for _, test := range tests {
+		if _, err := Base58ToU32(test.str); !errors.Is(err, test.err) {
+			t.Errorf("Base58ToU32(%q) error = %v, want %v", test.str, err, test.err)
+		}
+	}
+}
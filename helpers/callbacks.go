@@ -0,0 +1,37 @@
+package helpers
+
+import (
+	"sync"
+
+	"github.com/noxer/tinkerforge"
+)
+
+// Callbacks keeps track of the handlers a device control registered for its callbacks.
+// Setting a callback replaces only the handler set before through the same Callbacks,
+// handlers registered elsewhere for the same packet stay untouched.
+// The zero value is ready to use.
+type Callbacks struct {
+	mutex  sync.Mutex
+	tokens map[uint8]tinkerforge.HandlerToken
+}
+
+// Set registers 'handler' for the callback 'funcID' of the device with 'uid' and removes the
+// handler registered before. Passing nil only removes the previous handler.
+func (c *Callbacks) Set(t tinkerforge.Tinkerforge, uid uint32, funcID uint8, handler tinkerforge.Handler) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if token, ok := c.tokens[funcID]; ok {
+		t.RemoveHandler(token)
+		delete(c.tokens, funcID)
+	}
+
+	if handler == nil {
+		return
+	}
+
+	if c.tokens == nil {
+		c.tokens = make(map[uint8]tinkerforge.HandlerToken)
+	}
+	c.tokens[funcID] = t.Handler(uid, funcID, handler)
+}
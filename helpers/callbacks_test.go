@@ -0,0 +1,61 @@
+package helpers_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+// handlerFunc adapts a function to the tinkerforge.Handler interface
+type handlerFunc func(p *tinkerforge.Packet)
+
+func (f handlerFunc) Handle(p *tinkerforge.Packet) { f(p) }
+
+func TestCallbacks(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	// A handler registered by someone else for the same callback
+	other := make(chan struct{}, 3)
+	m.Handler(1, 10, handlerFunc(func(p *tinkerforge.Packet) { other <- struct{}{} }))
+
+	first := make(chan struct{}, 3)
+	second := make(chan struct{}, 3)
+
+	var c helpers.Callbacks
+	c.Set(m, 1, 10, handlerFunc(func(p *tinkerforge.Packet) { first <- struct{}{} }))
+	c.Set(m, 1, 10, handlerFunc(func(p *tinkerforge.Packet) { second <- struct{}{} }))
+
+	expect := func(name string, want map[string]bool) {
+		t.Helper()
+
+		if err := m.Fire(1, 10); err != nil {
+			t.Fatal(err)
+		}
+		for _, h := range []struct {
+			name string
+			c    chan struct{}
+		}{{"other", other}, {"first", first}, {"second", second}} {
+			select {
+			case <-h.c:
+				if !want[h.name] {
+					t.Errorf("%s: %s handler was called", name, h.name)
+				}
+			case <-time.After(50 * time.Millisecond):
+				if want[h.name] {
+					t.Errorf("%s: %s handler was not called", name, h.name)
+				}
+			}
+		}
+	}
+
+	// The second handler replaced the first one
+	expect("replace", map[string]bool{"other": true, "second": true})
+
+	// Removing the callback keeps the handler registered elsewhere
+	c.Set(m, 1, 10, nil)
+	expect("remove", map[string]bool{"other": true})
+}
@@ -4,78 +4,193 @@ package helpers
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/noxer/tinkerforge"
 )
 
 // Version represents a bricklet version number
-type Version [3]byte
+type Version = tinkerforge.Version
 
 // NewVersion creates a new Version array
 func NewVersion(main, sub, patch byte) Version {
 	return Version{main, sub, patch}
 }
 
-// String prints a version array
-func (v Version) String() string {
-	return fmt.Sprintf("%d.%d.%d", v[0], v[1], v[2])
+// ParseVersion parses a version string like "2.0.1"
+func ParseVersion(s string) (Version, error) {
+	var v Version
+
+	parts := strings.Split(s, ".")
+	if len(parts) != len(v) {
+		return Version{}, fmt.Errorf("version %q does not have the format main.sub.patch", s)
+	}
+
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 8)
+		if err != nil {
+			return Version{}, fmt.Errorf("invalid version %q: %v", s, err)
+		}
+		v[i] = byte(n)
+	}
+
+	return v, nil
 }
 
 var (
 	// DeviceIdentifiers is a map from the device ID to the name of the bricklet
 	DeviceIdentifiers = map[uint16]string{
-		11:  "Brick DC",
-		13:  "Brick Master",
-		14:  "Brick Servo",
-		15:  "Brick Stepper",
-		16:  "Brick IMU",
-		17:  "Brick RED",
-		21:  "Bricklet Ambient Light",
-		23:  "Bricklet Current12",
-		24:  "Bricklet Current25",
-		25:  "Bricklet Distance IR",
-		26:  "Bricklet Dual Relay",
-		27:  "Bricklet Humidity",
-		28:  "Bricklet IO-16",
-		29:  "Bricklet IO-4",
-		210: "Bricklet Joystick",
-		211: "Bricklet LCD 16x2",
-		212: "Bricklet LCD 20x4",
-		213: "Bricklet Linear Poti",
-		214: "Bricklet Piezo Buzzer",
-		215: "Bricklet Rotary Poti",
-		216: "Bricklet Temperature",
-		217: "Bricklet Temperature IR",
-		218: "Bricklet Voltage",
-		219: "Bricklet Analog In",
-		220: "Bricklet Analog Out",
-		221: "Bricklet Barometer",
-		222: "Bricklet GPS",
-		223: "Bricklet Industrial Digital In 4",
-		224: "Bricklet Industrial Digital Out 4",
-		225: "Bricklet Industrial Quad Relay",
-		226: "Bricklet PTC",
-		227: "Bricklet Voltage/Current",
-		228: "Bricklet Industrial Dual 0-20mA",
-		229: "Bricklet Distance US",
-		230: "Bricklet Dual Button",
-		231: "Bricklet LED Strip",
-		232: "Bricklet Moisture",
-		233: "Bricklet Motion Detector",
-		234: "Bricklet Multi Touch",
-		235: "Bricklet Remote Switch",
-		236: "Bricklet Rotary Encoder",
-		237: "Bricklet Segment Display 4x7",
-		238: "Bricklet Sound Intensity",
-		239: "Bricklet Tilt",
-		240: "Bricklet Hall Effect",
-		241: "Bricklet Line",
-		242: "Bricklet Piezo Speaker",
-		243: "Bricklet Color",
-		244: "Bricklet Solid State Relay",
-		245: "Bricklet Heart Rate",
-		246: "Bricklet NFC/RFID",
+		11:   "Brick DC",
+		13:   "Brick Master",
+		14:   "Brick Servo",
+		15:   "Brick Stepper",
+		16:   "Brick IMU",
+		17:   "Brick RED",
+		18:   "Brick IMU 2.0",
+		19:   "Brick Silent Stepper",
+		21:   "Bricklet Ambient Light",
+		23:   "Bricklet Current12",
+		24:   "Bricklet Current25",
+		25:   "Bricklet Distance IR",
+		26:   "Bricklet Dual Relay",
+		27:   "Bricklet Humidity",
+		28:   "Bricklet IO-16",
+		29:   "Bricklet IO-4",
+		111:  "Brick HAT",
+		112:  "Brick HAT Zero",
+		113:  "Brick ESP32",
+		115:  "Brick ESP32 Ethernet",
+		210:  "Bricklet Joystick",
+		211:  "Bricklet LCD 16x2",
+		212:  "Bricklet LCD 20x4",
+		213:  "Bricklet Linear Poti",
+		214:  "Bricklet Piezo Buzzer",
+		215:  "Bricklet Rotary Poti",
+		216:  "Bricklet Temperature",
+		217:  "Bricklet Temperature IR",
+		218:  "Bricklet Voltage",
+		219:  "Bricklet Analog In",
+		220:  "Bricklet Analog Out",
+		221:  "Bricklet Barometer",
+		222:  "Bricklet GPS",
+		223:  "Bricklet Industrial Digital In 4",
+		224:  "Bricklet Industrial Digital Out 4",
+		225:  "Bricklet Industrial Quad Relay",
+		226:  "Bricklet PTC",
+		227:  "Bricklet Voltage/Current",
+		228:  "Bricklet Industrial Dual 0-20mA",
+		229:  "Bricklet Distance US",
+		230:  "Bricklet Dual Button",
+		231:  "Bricklet LED Strip",
+		232:  "Bricklet Moisture",
+		233:  "Bricklet Motion Detector",
+		234:  "Bricklet Multi Touch",
+		235:  "Bricklet Remote Switch",
+		236:  "Bricklet Rotary Encoder",
+		237:  "Bricklet Segment Display 4x7",
+		238:  "Bricklet Sound Intensity",
+		239:  "Bricklet Tilt",
+		240:  "Bricklet Hall Effect",
+		241:  "Bricklet Line",
+		242:  "Bricklet Piezo Speaker",
+		243:  "Bricklet Color",
+		244:  "Bricklet Solid State Relay",
+		245:  "Bricklet Heart Rate",
+		246:  "Bricklet NFC/RFID",
+		249:  "Bricklet Industrial Dual Analog In",
+		250:  "Bricklet Accelerometer",
+		251:  "Bricklet Analog In 2.0",
+		253:  "Bricklet Load Cell",
+		254:  "Bricklet RS232",
+		255:  "Bricklet Laser Range Finder",
+		256:  "Bricklet Analog Out 2.0",
+		258:  "Bricklet Industrial Analog Out",
+		259:  "Bricklet Ambient Light 2.0",
+		260:  "Bricklet Dust Detector",
+		262:  "Bricklet CO2",
+		263:  "Bricklet OLED 128x64",
+		264:  "Bricklet OLED 64x48",
+		265:  "Bricklet UV Light",
+		266:  "Bricklet Thermocouple",
+		267:  "Bricklet Motorized Linear Poti",
+		268:  "Bricklet Real-Time Clock",
+		270:  "Bricklet CAN",
+		271:  "Bricklet RGB LED",
+		272:  "Bricklet RGB LED Matrix",
+		276:  "Bricklet GPS 2.0",
+		277:  "Bricklet RS485",
+		278:  "Bricklet Thermal Imaging",
+		279:  "Bricklet XMC1400 Breakout",
+		282:  "Bricklet RGB LED Button",
+		283:  "Bricklet Humidity 2.0",
+		284:  "Bricklet Industrial Dual Relay",
+		285:  "Bricklet DMX",
+		286:  "Bricklet NFC",
+		288:  "Bricklet Outdoor Weather",
+		289:  "Bricklet Remote Switch 2.0",
+		290:  "Bricklet Sound Pressure Level",
+		291:  "Bricklet Temperature IR 2.0",
+		292:  "Bricklet Motion Detector 2.0",
+		293:  "Bricklet Industrial Counter",
+		294:  "Bricklet Rotary Encoder 2.0",
+		295:  "Bricklet Analog In 3.0",
+		296:  "Bricklet Solid State Relay 2.0",
+		297:  "Bricklet Air Quality",
+		298:  "Bricklet LCD 128x64",
+		299:  "Bricklet Distance US 2.0",
+		2100: "Bricklet Industrial Digital In 4 2.0",
+		2101: "Bricklet PTC 2.0",
+		2102: "Bricklet Industrial Quad Relay 2.0",
+		2103: "Bricklet LED Strip 2.0",
+		2104: "Bricklet Load Cell 2.0",
+		2105: "Bricklet Voltage/Current 2.0",
+		2106: "Bricklet Real-Time Clock 2.0",
+		2107: "Bricklet CAN 2.0",
+		2108: "Bricklet RS232 2.0",
+		2109: "Bricklet Thermocouple 2.0",
+		2110: "Bricklet Particulate Matter",
+		2111: "Bricklet IO-4 2.0",
+		2112: "Bricklet OLED 128x64 2.0",
+		2113: "Bricklet Temperature 2.0",
+		2114: "Bricklet IO-16 2.0",
+		2115: "Bricklet Analog Out 3.0",
+		2116: "Bricklet Industrial Analog Out 2.0",
+		2117: "Bricklet Barometer 2.0",
+		2118: "Bricklet UV Light 2.0",
+		2119: "Bricklet Dual Button 2.0",
+		2120: "Bricklet Industrial Dual 0-20mA 2.0",
+		2121: "Bricklet Industrial Dual Analog In 2.0",
+		2122: "Bricklet Isolator",
+		2123: "Bricklet One Wire",
+		2124: "Bricklet Industrial Digital Out 4 2.0",
+		2125: "Bricklet Distance IR 2.0",
+		2127: "Bricklet RGB LED 2.0",
+		2128: "Bricklet Color 2.0",
+		2129: "Bricklet Multi Touch 2.0",
+		2130: "Bricklet Accelerometer 2.0",
+		2131: "Bricklet Ambient Light 3.0",
+		2132: "Bricklet Hall Effect 2.0",
+		2137: "Bricklet Segment Display 4x7 2.0",
+		2138: "Bricklet Joystick 2.0",
+		2139: "Bricklet Linear Poti 2.0",
+		2140: "Bricklet Rotary Poti 2.0",
+		2144: "Bricklet Laser Range Finder 2.0",
+		2145: "Bricklet Piezo Speaker 2.0",
+		2146: "Bricklet E-Paper 296x128",
+		2147: "Bricklet CO2 2.0",
+		2152: "Bricklet Energy Monitor",
+		2153: "Bricklet Compass",
+		2156: "Bricklet Performance DC",
+		2157: "Bricklet Servo 2.0",
+		2161: "Bricklet IMU 3.0",
+		2162: "Bricklet Industrial Dual AC Relay",
+		2164: "Bricklet Industrial PTC",
+		2165: "Bricklet DC 2.0",
+		2166: "Bricklet Silent Stepper 2.0",
+		2171: "Bricklet GPS 3.0",
+		2174: "Bricklet Industrial Dual AC In",
 	}
 )
 
@@ -116,7 +231,100 @@ func GetIdentity(t tinkerforge.Tinkerforge, uid uint32) (*BrickletIdentity, erro
 	return i, nil
 }
 
-// DeviceName translates the device ID into a human readable name
+// Enumerate triggers an enumeration and collects the devices answering until no new device
+// answered for 'timeout'. Devices reporting a disconnect are ignored.
+func Enumerate(t tinkerforge.Tinkerforge, timeout time.Duration) ([]BrickletIdentity, error) {
+	found := make(chan tinkerforge.Enumeration)
+	done := make(chan struct{})
+	defer close(done)
+
+	// Register the enumerate callback (function #253)
+	token := t.Handler(0, 253, tinkerforge.EnumerateHandler(func(e tinkerforge.Enumeration) {
+		select {
+		case found <- e:
+		case <-done:
+		}
+	}))
+	defer t.RemoveHandler(token)
+
+	if err := t.Enumerate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		devices []BrickletIdentity
+		seen    = make(map[string]bool)
+		idle    = time.After(timeout)
+	)
+
+	for {
+		select {
+		case e := <-found:
+			if e.EnumerationType == tinkerforge.EnumerationDisconnected || seen[e.UID] {
+				continue
+			}
+			seen[e.UID] = true
+
+			devices = append(devices, BrickletIdentity{
+				UID:              e.UID,
+				ConnectedUID:     e.ConnectedUID,
+				Position:         e.Position,
+				HardwareVersion:  e.HardwareVersion,
+				FirmwareVersion:  e.FirmwareVersion,
+				DeviceIdentifier: e.DeviceIdentifier,
+			})
+
+			// Wait for further devices
+			idle = time.After(timeout)
+
+		case <-idle:
+			return devices, nil
+		}
+	}
+}
+
+// DeviceName translates the device ID into a human readable name, unknown IDs
+// are reported as "Unknown (<id>)"
 func (i *BrickletIdentity) DeviceName() string {
-	return DeviceIdentifiers[i.DeviceIdentifier]
+	if name, ok := DeviceName(i.DeviceIdentifier); ok {
+		return name
+	}
+
+	return fmt.Sprintf("Unknown (%d)", i.DeviceIdentifier)
+}
+
+// DeviceName translates the device ID into a human readable name, ok is false for unknown IDs
+func DeviceName(id uint16) (name string, ok bool) {
+	name, ok = DeviceIdentifiers[id]
+	return
+}
+
+// deviceIDs maps the lower case device names to their IDs
+var deviceIDs map[string]uint16
+
+func init() {
+	deviceIDs = make(map[string]uint16, len(DeviceIdentifiers))
+	for id, name := range DeviceIdentifiers {
+		deviceIDs[strings.ToLower(name)] = id
+	}
 }
+
+// DeviceID translates a device name (e.g. "Bricklet LED Strip") into its device ID.
+// Surrounding spaces and case are ignored, ok is false for unknown names.
+func DeviceID(name string) (id uint16, ok bool) {
+	id, ok = deviceIDs[strings.ToLower(strings.TrimSpace(name))]
+	return
+}
+
+const (
+	// ThresholdOff disables a threshold callback
+	ThresholdOff byte = 'x'
+	// ThresholdOutside triggers when the value is outside of min and max
+	ThresholdOutside byte = 'o'
+	// ThresholdInside triggers when the value is inside of min and max
+	ThresholdInside byte = 'i'
+	// ThresholdSmaller triggers when the value is smaller than min (max is ignored)
+	ThresholdSmaller byte = '<'
+	// ThresholdGreater triggers when the value is greater than min (max is ignored)
+	ThresholdGreater byte = '>'
+)
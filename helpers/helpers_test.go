@@ -0,0 +1,69 @@
+package helpers
+
+import "testing"
+
+func TestDeviceIdentifiers(t *testing.T) {
+	tests := []struct {
+		id   uint16
+		name string
+	}{
+		{13, "Brick Master"},
+		{231, "Bricklet LED Strip"},
+		{2117, "Bricklet Barometer 2.0"},
+		{2119, "Bricklet Dual Button 2.0"},
+		{2120, "Bricklet Industrial Dual 0-20mA 2.0"},
+		{2121, "Bricklet Industrial Dual Analog In 2.0"},
+		{2122, "Bricklet Isolator"},
+		{2123, "Bricklet One Wire"},
+		{2128, "Bricklet Color 2.0"},
+		{2129, "Bricklet Multi Touch 2.0"},
+		{2145, "Bricklet Piezo Speaker 2.0"},
+		{2146, "Bricklet E-Paper 296x128"},
+		{2162, "Bricklet Industrial Dual AC Relay"},
+		{2164, "Bricklet Industrial PTC"},
+		{2171, "Bricklet GPS 3.0"},
+	}
+
+	for _, test := range tests {
+		if name, ok := DeviceName(test.id); !ok || name != test.name {
+			t.Errorf("DeviceName(%d) = %q, %t, want %q", test.id, name, ok, test.name)
+		}
+		if id, ok := DeviceID(test.name); !ok || id != test.id {
+			t.Errorf("DeviceID(%q) = %d, %t, want %d", test.name, id, ok, test.id)
+		}
+	}
+}
+
+func TestDeviceIDUnknown(t *testing.T) {
+	if id, ok := DeviceID("Bricklet Flux Capacitor"); ok {
+		t.Errorf("DeviceID() = %d, true for an unknown name", id)
+	}
+	if id, ok := DeviceID("  bricklet led STRIP "); !ok || id != 231 {
+		t.Errorf("DeviceID() = %d, %t, want 231 ignoring case and spaces", id, ok)
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		s    string
+		want Version
+	}{
+		{"2.0.1", Version{2, 0, 1}},
+		{"0.0.0", Version{0, 0, 0}},
+		{"255.255.255", Version{255, 255, 255}},
+		{"1.10.02", Version{1, 10, 2}},
+	}
+
+	for _, test := range tests {
+		if v, err := ParseVersion(test.s); err != nil || v != test.want {
+			t.Errorf("ParseVersion(%q) = %v, %v, want %v", test.s, v, err, test.want)
+		}
+	}
+
+	// Malformed versions
+	for _, s := range []string{"", "2", "2.0", "2.0.1.3", "2..1", "2.0.x", "2.0.-1", "256.0.0", " 2.0.1", "v2.0.1"} {
+		if v, err := ParseVersion(s); err == nil {
+			t.Errorf("ParseVersion(%q) = %v, want an error", s, v)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+// Package humidity has control routines for the Humidity Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package humidity
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// Humidity is a control structure for Humidity Bricklets
+type Humidity struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+// New creates a new humidity control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*Humidity, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &Humidity{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// GetHumidity returns the relative humidity of the sensor in %RH/10.
+func (h *Humidity) GetHumidity() (uint16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(h.uid, 1, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := h.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the humidity
+	var humidity uint16
+	if err = res.Decode(&humidity); err != nil {
+		return 0, err
+	}
+
+	return humidity, nil
+}
+
+// GetAnalogValue returns the raw value of the analog-digital converter (0 to 4095).
+func (h *Humidity) GetAnalogValue() (uint16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(h.uid, 2, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := h.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the value
+	var value uint16
+	if err = res.Decode(&value); err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+// SetHumidityCallbackPeriod sets the period in ms with which the humidity callback is triggered.
+// The callback is only triggered if the humidity changed. A value of 0 turns the callback off.
+func (h *Humidity) SetHumidityCallbackPeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(h.uid, 3, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = h.t.Send(p)
+	return err
+}
+
+// SetHumidityCallbackThreshold sets the threshold for the humidity reached callback.
+// 'option' is one of the helpers.Threshold* constants.
+func (h *Humidity) SetHumidityCallbackThreshold(option byte, min, max uint16) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(h.uid, 7, false, option, min, max)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = h.t.Send(p)
+	return err
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (h *Humidity) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(h.t, h.uid)
+	return i, err
+}
+
+type humidityHandler func(uint16)
+
+func (f humidityHandler) Handle(p *tinkerforge.Packet) {
+
+	var humidity uint16
+
+	if p.Decode(&humidity) != nil {
+		return
+	}
+	f(humidity)
+
+}
+
+// CallbackHumidity is a convenience function for registering
+// a handler to be called periodically with the humidity.
+// The period is set with SetHumidityCallbackPeriod.
+func (h *Humidity) CallbackHumidity(handler func(uint16)) {
+
+	if handler == nil {
+		h.callbacks.Set(h.t, h.uid, 13, nil)
+	} else {
+		h.callbacks.Set(h.t, h.uid, 13, humidityHandler(handler))
+	}
+
+}
+
+// CallbackHumidityReached is a convenience function for registering
+// a handler to be called when the threshold set by SetHumidityCallbackThreshold is reached.
+func (h *Humidity) CallbackHumidityReached(handler func(uint16)) {
+
+	if handler == nil {
+		h.callbacks.Set(h.t, h.uid, 15, nil)
+	} else {
+		h.callbacks.Set(h.t, h.uid, 15, humidityHandler(handler))
+	}
+
+}
@@ -0,0 +1,94 @@
+package humidity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/noxer/tinkerforge/helpers"
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+func TestWire(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	h, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.Expect(t, h.uid, []tinkerforgetest.Expectation{
+		{Name: "SetHumidityCallbackPeriod", Call: func() error { return h.SetHumidityCallbackPeriod(1000) }, FuncID: 3, Payload: []byte{0xe8, 0x03, 0, 0}},
+		{Name: "SetHumidityCallbackThreshold", Call: func() error { return h.SetHumidityCallbackThreshold(helpers.ThresholdOutside, 300, 600) }, FuncID: 7, Payload: []byte{'o', 0x2c, 0x01, 0x58, 0x02}},
+	})
+}
+
+func TestGetters(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	h, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		call   func() (uint16, error)
+		funcID uint8
+	}{
+		{"GetHumidity", h.GetHumidity, 1},
+		{"GetAnalogValue", h.GetAnalogValue, 2},
+	}
+
+	for _, test := range tests {
+		if err := m.Respond(h.uid, test.funcID, uint16(421)); err != nil {
+			t.Fatal(err)
+		}
+
+		value, err := test.call()
+		if err != nil || value != 421 {
+			t.Errorf("%s() = %d, %v, want 421", test.name, value, err)
+		}
+	}
+}
+
+func TestCallbacks(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	h, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	humidity := make(chan uint16, 1)
+	reached := make(chan uint16, 1)
+	h.CallbackHumidity(func(value uint16) { humidity <- value })
+	h.CallbackHumidityReached(func(value uint16) { reached <- value })
+
+	if err := m.Fire(h.uid, 13, uint16(421)); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Fire(h.uid, 15, uint16(650)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		name string
+		got  chan uint16
+		want uint16
+	}{
+		{"humidity", humidity, 421},
+		{"humidity reached", reached, 650},
+	} {
+		select {
+		case value := <-c.got:
+			if value != c.want {
+				t.Errorf("%s callback got %d, want %d", c.name, value, c.want)
+			}
+		case <-time.After(time.Second):
+			t.Errorf("%s callback was not called", c.name)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+// Package imu has control routines for the IMU Brick
+// Author: Tim Scheuermann (https://github.com/noxer)
+package imu
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// IMU is a control structure for IMU Bricks
+type IMU struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+// New creates a new IMU control for the brick with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*IMU, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &IMU{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// getVector requests function 'funcID' and decodes three int16 values from the answer.
+func (i *IMU) getVector(funcID uint8) (x, y, z int16, err error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, funcID, true)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	// Send the packet
+	res, err := i.t.Send(p)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	// Decode the vector
+	if err = res.Decode(&x, &y, &z); err != nil {
+		return 0, 0, 0, err
+	}
+
+	return x, y, z, nil
+}
+
+// GetAcceleration returns the calibrated acceleration for the x, y and z axis in g/1000.
+func (i *IMU) GetAcceleration() (x, y, z int16, err error) {
+	return i.getVector(1)
+}
+
+// GetMagneticField returns the calibrated magnetic field for the x, y and z axis in mG.
+func (i *IMU) GetMagneticField() (x, y, z int16, err error) {
+	return i.getVector(2)
+}
+
+// GetAngularVelocity returns the calibrated angular velocity for the x, y and z axis in °/14.375s.
+func (i *IMU) GetAngularVelocity() (x, y, z int16, err error) {
+	return i.getVector(3)
+}
+
+// GetOrientation returns the current orientation (roll, pitch, yaw) of the IMU in °/100.
+func (i *IMU) GetOrientation() (roll, pitch, yaw int16, err error) {
+	return i.getVector(5)
+}
+
+// GetQuaternion returns the current orientation of the IMU as a quaternion.
+func (i *IMU) GetQuaternion() (w, x, y, z float32, err error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 6, true)
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+
+	// Send the packet
+	res, err := i.t.Send(p)
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+
+	// Decode the quaternion, the brick sends w last
+	if err = res.Decode(&x, &y, &z, &w); err != nil {
+		return 0, 0, 0, 0, err
+	}
+
+	return w, x, y, z, nil
+}
+
+// SetConvergenceSpeed sets the convergence speed of the sensor fusion in °/s.
+func (i *IMU) SetConvergenceSpeed(speed uint16) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 15, false, speed)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = i.t.Send(p)
+	return err
+}
+
+// SetQuaternionPeriod sets the period in ms with which the quaternion callback is triggered.
+// A value of 0 turns the callback off.
+func (i *IMU) SetQuaternionPeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 29, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = i.t.Send(p)
+	return err
+}
+
+// GetIdentity returns the position information of the brick and its identifier.
+func (i *IMU) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	id, err := helpers.GetIdentity(i.t, i.uid)
+	return id, err
+}
+
+type quaternionHandler func(w, x, y, z float32)
+
+func (f quaternionHandler) Handle(p *tinkerforge.Packet) {
+
+	var w, x, y, z float32
+
+	if p.Decode(&x, &y, &z, &w) != nil {
+		return
+	}
+	f(w, x, y, z)
+
+}
+
+// CallbackQuaternion is a convenience function for registering
+// a handler to be called periodically with the current quaternion.
+// The period is set with SetQuaternionPeriod.
+func (i *IMU) CallbackQuaternion(handler func(w, x, y, z float32)) {
+
+	if handler == nil {
+		i.callbacks.Set(i.t, i.uid, 36, nil)
+	} else {
+		i.callbacks.Set(i.t, i.uid, 36, quaternionHandler(handler))
+	}
+
+}
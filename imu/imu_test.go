@@ -0,0 +1,103 @@
+package imu
+
+import (
+	"testing"
+	"time"
+
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+func TestWire(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	i, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.Expect(t, i.uid, []tinkerforgetest.Expectation{
+		{Name: "SetConvergenceSpeed", Call: func() error { return i.SetConvergenceSpeed(30) }, FuncID: 15, Payload: []byte{30, 0}},
+		{Name: "SetQuaternionPeriod", Call: func() error { return i.SetQuaternionPeriod(1000) }, FuncID: 29, Payload: []byte{0xe8, 0x03, 0, 0}},
+	})
+}
+
+func TestGetVector(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	i, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		call   func() (int16, int16, int16, error)
+		funcID uint8
+	}{
+		{"GetAcceleration", i.GetAcceleration, 1},
+		{"GetMagneticField", i.GetMagneticField, 2},
+		{"GetAngularVelocity", i.GetAngularVelocity, 3},
+		{"GetOrientation", i.GetOrientation, 5},
+	}
+
+	for _, test := range tests {
+		if err := m.Respond(i.uid, test.funcID, int16(-1), int16(2), int16(-3)); err != nil {
+			t.Fatal(err)
+		}
+
+		x, y, z, err := test.call()
+		if err != nil || x != -1 || y != 2 || z != -3 {
+			t.Errorf("%s() = %d, %d, %d, %v, want -1, 2, -3", test.name, x, y, z, err)
+		}
+	}
+}
+
+func TestGetQuaternion(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	i, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The brick sends x, y, z and w last
+	if err := m.Respond(i.uid, 6, float32(0.25), float32(0.5), float32(0.75), float32(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	w, x, y, z, err := i.GetQuaternion()
+	if err != nil || w != 1 || x != 0.25 || y != 0.5 || z != 0.75 {
+		t.Errorf("GetQuaternion() = %v, %v, %v, %v, %v, want 1, 0.25, 0.5, 0.75", w, x, y, z, err)
+	}
+}
+
+func TestCallbackQuaternion(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	i, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(chan [4]float32, 1)
+	i.CallbackQuaternion(func(w, x, y, z float32) {
+		got <- [4]float32{w, x, y, z}
+	})
+
+	if err := m.Fire(i.uid, 36, float32(0.25), float32(0.5), float32(0.75), float32(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case q := <-got:
+		if q != [4]float32{1, 0.25, 0.5, 0.75} {
+			t.Errorf("got quaternion %v, want [1 0.25 0.5 0.75]", q)
+		}
+	case <-time.After(time.Second):
+		t.Error("quaternion callback was not called")
+	}
+}
@@ -0,0 +1,152 @@
+// Package industrialdigitalin4 has control routines for the Industrial Digital In 4 Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package industrialdigitalin4
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// IndustrialDigitalIn4 is a control structure for Industrial Digital In 4 Bricklets
+type IndustrialDigitalIn4 struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+// GroupNone marks an unused entry in the group configuration
+const GroupNone byte = 'n'
+
+// New creates a new industrial digital in 4 control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*IndustrialDigitalIn4, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &IndustrialDigitalIn4{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// GetValue returns the input value of the pins as a bitmask.
+// If bricklets are grouped, bits 4 to 15 represent the pins of the other bricklets of the group.
+func (i *IndustrialDigitalIn4) GetValue() (uint16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 1, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := i.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the value mask
+	var valueMask uint16
+	if err = res.Decode(&valueMask); err != nil {
+		return 0, err
+	}
+
+	return valueMask, nil
+}
+
+// SetGroup groups up to four bricklets. Each entry is the port ('a' to 'd') of a bricklet
+// or GroupNone. The pins of the group are then addressed as a 16 bit value.
+func (i *IndustrialDigitalIn4) SetGroup(group [4]byte) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 2, false, group)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = i.t.Send(p)
+	return err
+}
+
+// GetGroup returns the group as set by SetGroup.
+func (i *IndustrialDigitalIn4) GetGroup() ([4]byte, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 3, true)
+	if err != nil {
+		return [4]byte{}, err
+	}
+
+	// Send the packet
+	res, err := i.t.Send(p)
+	if err != nil {
+		return [4]byte{}, err
+	}
+
+	// Decode the group
+	var group [4]byte
+	if err = res.Decode(&group); err != nil {
+		return [4]byte{}, err
+	}
+
+	return group, nil
+}
+
+// SetDebouncePeriod sets the debounce period of the interrupt callback in ms.
+func (i *IndustrialDigitalIn4) SetDebouncePeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 5, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = i.t.Send(p)
+	return err
+}
+
+// SetInterrupt enables the interrupt callback for the pins set in 'interruptMask'.
+func (i *IndustrialDigitalIn4) SetInterrupt(interruptMask uint16) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 7, false, interruptMask)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = i.t.Send(p)
+	return err
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (i *IndustrialDigitalIn4) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	id, err := helpers.GetIdentity(i.t, i.uid)
+	return id, err
+}
+
+type interruptHandler func(uint16, uint16)
+
+func (f interruptHandler) Handle(p *tinkerforge.Packet) {
+
+	var (
+		interruptMask uint16
+		valueMask     uint16
+	)
+
+	if p.Decode(&interruptMask, &valueMask) != nil {
+		return
+	}
+	f(interruptMask, valueMask)
+
+}
+
+// CallbackInterrupt is a convenience function for registering
+// a handler to be called when a pin enabled by SetInterrupt changes.
+func (i *IndustrialDigitalIn4) CallbackInterrupt(handler func(interruptMask, valueMask uint16)) {
+
+	if handler == nil {
+		i.callbacks.Set(i.t, i.uid, 9, nil)
+	} else {
+		i.callbacks.Set(i.t, i.uid, 9, interruptHandler(handler))
+	}
+
+}
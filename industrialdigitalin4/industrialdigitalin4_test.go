@@ -0,0 +1,32 @@
+package industrialdigitalin4
+
+import (
+	"testing"
+
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+func TestGroup(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	i, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	group := [4]byte{'a', 'c', GroupNone, GroupNone}
+
+	m.Expect(t, i.uid, []tinkerforgetest.Expectation{
+		{Name: "SetGroup", Call: func() error { return i.SetGroup(group) }, FuncID: 2, Payload: []byte{'a', 'c', 'n', 'n'}},
+	})
+
+	if err := m.Respond(i.uid, 3, group); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := i.GetGroup()
+	if err != nil || got != group {
+		t.Errorf("GetGroup() = %q, %v, want %q", got, err, group)
+	}
+}
@@ -0,0 +1,199 @@
+// Package industrialdigitalout4 has control routines for the Industrial Digital Out 4 Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package industrialdigitalout4
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// IndustrialDigitalOut4 is a control structure for Industrial Digital Out 4 Bricklets
+type IndustrialDigitalOut4 struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+// GroupNone marks an unused entry in the group configuration
+const GroupNone byte = 'n'
+
+// New creates a new industrial digital out 4 control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*IndustrialDigitalOut4, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &IndustrialDigitalOut4{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// SetValue sets the pins to the bits of 'valueMask'.
+// If bricklets are grouped, bits 4 to 15 address the pins of the other bricklets of the group.
+func (i *IndustrialDigitalOut4) SetValue(valueMask uint16) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 1, false, valueMask)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = i.t.Send(p)
+	return err
+}
+
+// GetValue returns the state of the pins as a bitmask.
+func (i *IndustrialDigitalOut4) GetValue() (uint16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 2, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := i.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the value mask
+	var valueMask uint16
+	if err = res.Decode(&valueMask); err != nil {
+		return 0, err
+	}
+
+	return valueMask, nil
+}
+
+// SetMonoflop sets the pins selected by 'selectionMask' to the bits of 'valueMask'
+// and changes them back after 'time' ms.
+func (i *IndustrialDigitalOut4) SetMonoflop(selectionMask, valueMask uint16, time uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 3, false, selectionMask, valueMask, time)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = i.t.Send(p)
+	return err
+}
+
+// GetMonoflop returns the value, the configured time and the remaining time in ms of a monoflop on 'pin'.
+func (i *IndustrialDigitalOut4) GetMonoflop(pin uint8) (value uint16, time, timeRemaining uint32, err error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 4, true, pin)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	// Send the packet
+	res, err := i.t.Send(p)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	// Decode the monoflop
+	if err = res.Decode(&value, &time, &timeRemaining); err != nil {
+		return 0, 0, 0, err
+	}
+
+	return value, time, timeRemaining, nil
+}
+
+// SetGroup groups up to four bricklets. Each entry is the port ('a' to 'd') of a bricklet
+// or GroupNone. The pins of the group are then addressed as a 16 bit value.
+func (i *IndustrialDigitalOut4) SetGroup(group [4]byte) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 5, false, group)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = i.t.Send(p)
+	return err
+}
+
+// GetGroup returns the group as set by SetGroup.
+func (i *IndustrialDigitalOut4) GetGroup() ([4]byte, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 6, true)
+	if err != nil {
+		return [4]byte{}, err
+	}
+
+	// Send the packet
+	res, err := i.t.Send(p)
+	if err != nil {
+		return [4]byte{}, err
+	}
+
+	// Decode the group
+	var group [4]byte
+	if err = res.Decode(&group); err != nil {
+		return [4]byte{}, err
+	}
+
+	return group, nil
+}
+
+// GetAvailableForGroup returns a bitmask of the ports (bit 0 for 'a' to bit 3 for 'd')
+// with bricklets that can be grouped.
+func (i *IndustrialDigitalOut4) GetAvailableForGroup() (uint8, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 7, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := i.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the available ports
+	var available uint8
+	if err = res.Decode(&available); err != nil {
+		return 0, err
+	}
+
+	return available, nil
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (i *IndustrialDigitalOut4) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	id, err := helpers.GetIdentity(i.t, i.uid)
+	return id, err
+}
+
+type monoflopDoneHandler func(uint16, uint16)
+
+func (f monoflopDoneHandler) Handle(p *tinkerforge.Packet) {
+
+	var (
+		selectionMask uint16
+		valueMask     uint16
+	)
+
+	if p.Decode(&selectionMask, &valueMask) != nil {
+		return
+	}
+	f(selectionMask, valueMask)
+
+}
+
+// CallbackMonoflopDone is a convenience function for registering
+// a handler to be called when a monoflop timer ran out.
+func (i *IndustrialDigitalOut4) CallbackMonoflopDone(handler func(selectionMask, valueMask uint16)) {
+
+	if handler == nil {
+		i.callbacks.Set(i.t, i.uid, 8, nil)
+	} else {
+		i.callbacks.Set(i.t, i.uid, 8, monoflopDoneHandler(handler))
+	}
+
+}
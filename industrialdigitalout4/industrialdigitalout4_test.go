@@ -0,0 +1,94 @@
+package industrialdigitalout4
+
+import (
+	"testing"
+	"time"
+
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+func TestWire(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	i, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.Expect(t, i.uid, []tinkerforgetest.Expectation{
+		{Name: "SetValue", Call: func() error { return i.SetValue(0x0105) }, FuncID: 1, Payload: []byte{0x05, 0x01}},
+		{Name: "SetMonoflop", Call: func() error { return i.SetMonoflop(0x0003, 0x0001, 1500) }, FuncID: 3, Payload: []byte{0x03, 0, 0x01, 0, 0xdc, 0x05, 0, 0}},
+		{Name: "SetGroup", Call: func() error { return i.SetGroup([4]byte{'a', 'b', GroupNone, GroupNone}) }, FuncID: 5, Payload: []byte{'a', 'b', 'n', 'n'}},
+	})
+}
+
+func TestGetters(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	i, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Respond(i.uid, 2, uint16(0x0105)); err != nil {
+		t.Fatal(err)
+	}
+	value, err := i.GetValue()
+	if err != nil || value != 0x0105 {
+		t.Errorf("GetValue() = %#x, %v, want 0x105", value, err)
+	}
+
+	if err := m.Respond(i.uid, 4, uint16(1), uint32(1500), uint32(700)); err != nil {
+		t.Fatal(err)
+	}
+	value, total, remaining, err := i.GetMonoflop(0)
+	if err != nil || value != 1 || total != 1500 || remaining != 700 {
+		t.Errorf("GetMonoflop(0) = %d, %d, %d, %v, want 1, 1500, 700", value, total, remaining, err)
+	}
+
+	if err := m.Respond(i.uid, 6, [4]byte{'a', 'n', 'n', 'n'}); err != nil {
+		t.Fatal(err)
+	}
+	group, err := i.GetGroup()
+	if err != nil || group != [4]byte{'a', 'n', 'n', 'n'} {
+		t.Errorf("GetGroup() = %q, %v, want \"annn\"", group, err)
+	}
+
+	if err := m.Respond(i.uid, 7, uint8(0x05)); err != nil {
+		t.Fatal(err)
+	}
+	available, err := i.GetAvailableForGroup()
+	if err != nil || available != 0x05 {
+		t.Errorf("GetAvailableForGroup() = %#x, %v, want 0x5", available, err)
+	}
+}
+
+func TestCallbackMonoflopDone(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	i, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(chan [2]uint16, 1)
+	i.CallbackMonoflopDone(func(selectionMask, valueMask uint16) {
+		got <- [2]uint16{selectionMask, valueMask}
+	})
+
+	if err := m.Fire(i.uid, 8, uint16(0x0003), uint16(0x0002)); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case masks := <-got:
+		if masks != [2]uint16{0x0003, 0x0002} {
+			t.Errorf("got masks %#x, want [0x3 0x2]", masks)
+		}
+	case <-time.After(time.Second):
+		t.Error("monoflop done callback was not called")
+	}
+}
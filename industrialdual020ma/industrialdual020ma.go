@@ -0,0 +1,162 @@
+// Package industrialdual020ma has control routines for the Industrial Dual 0-20mA Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package industrialdual020ma
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// IndustrialDual020mA is a control structure for Industrial Dual 0-20mA Bricklets
+type IndustrialDual020mA struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+// New creates a new industrial dual 0-20mA control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*IndustrialDual020mA, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &IndustrialDual020mA{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// GetCurrent returns the current of 'sensor' (0 or 1) in nA.
+func (i *IndustrialDual020mA) GetCurrent(sensor uint8) (int32, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 1, true, sensor)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := i.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the current
+	var current int32
+	if err = res.Decode(&current); err != nil {
+		return 0, err
+	}
+
+	return current, nil
+}
+
+// SetCurrentCallbackPeriod sets the period in ms with which the current callback is triggered for 'sensor'.
+// The callback is only triggered if the current changed. A value of 0 turns the callback off.
+func (i *IndustrialDual020mA) SetCurrentCallbackPeriod(sensor uint8, period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 2, false, sensor, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = i.t.Send(p)
+	return err
+}
+
+// SetCurrentCallbackThreshold sets the threshold of 'sensor' for the current reached callback.
+// 'option' is one of the helpers.Threshold* constants.
+func (i *IndustrialDual020mA) SetCurrentCallbackThreshold(sensor uint8, option byte, min, max int32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 4, false, sensor, option, min, max)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = i.t.Send(p)
+	return err
+}
+
+// SetSampleRate sets the sample rate (0: 240, 1: 60, 2: 15, 3: 4 samples per second).
+func (i *IndustrialDual020mA) SetSampleRate(rate uint8) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 8, false, rate)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = i.t.Send(p)
+	return err
+}
+
+// GetSampleRate returns the sample rate as set by SetSampleRate.
+func (i *IndustrialDual020mA) GetSampleRate() (uint8, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 9, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := i.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the sample rate
+	var rate uint8
+	if err = res.Decode(&rate); err != nil {
+		return 0, err
+	}
+
+	return rate, nil
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (i *IndustrialDual020mA) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	id, err := helpers.GetIdentity(i.t, i.uid)
+	return id, err
+}
+
+type currentHandler func(uint8, int32)
+
+func (f currentHandler) Handle(p *tinkerforge.Packet) {
+
+	var (
+		sensor  uint8
+		current int32
+	)
+
+	if p.Decode(&sensor, &current) != nil {
+		return
+	}
+	f(sensor, current)
+
+}
+
+// CallbackCurrent is a convenience function for registering
+// a handler to be called periodically with the current of a sensor.
+// The period is set with SetCurrentCallbackPeriod.
+func (i *IndustrialDual020mA) CallbackCurrent(handler func(sensor uint8, current int32)) {
+
+	if handler == nil {
+		i.callbacks.Set(i.t, i.uid, 10, nil)
+	} else {
+		i.callbacks.Set(i.t, i.uid, 10, currentHandler(handler))
+	}
+
+}
+
+// CallbackCurrentReached is a convenience function for registering
+// a handler to be called when the threshold set by SetCurrentCallbackThreshold is reached.
+func (i *IndustrialDual020mA) CallbackCurrentReached(handler func(sensor uint8, current int32)) {
+
+	if handler == nil {
+		i.callbacks.Set(i.t, i.uid, 11, nil)
+	} else {
+		i.callbacks.Set(i.t, i.uid, 11, currentHandler(handler))
+	}
+
+}
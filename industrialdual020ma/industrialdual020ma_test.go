@@ -0,0 +1,48 @@
+package industrialdual020ma
+
+import (
+	"testing"
+	"time"
+
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+func TestCallbacks(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	i, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	current := make(chan int32, 1)
+	reached := make(chan int32, 1)
+	i.CallbackCurrent(func(sensor uint8, c int32) { current <- c })
+	i.CallbackCurrentReached(func(sensor uint8, c int32) { reached <- c })
+
+	tests := []struct {
+		name   string
+		funcID uint8
+		value  int32
+		c      chan int32
+	}{
+		{"CallbackCurrent", 10, 4000000, current},
+		{"CallbackCurrentReached", 11, 20000000, reached},
+	}
+
+	for _, test := range tests {
+		if err := m.Fire(i.uid, test.funcID, uint8(1), test.value); err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case v := <-test.c:
+			if v != test.value {
+				t.Errorf("%s: got %d, want %d", test.name, v, test.value)
+			}
+		case <-time.After(time.Second):
+			t.Errorf("%s was not called for function ID %d", test.name, test.funcID)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Package ks0066u converts strings into the character set of the KS0066U
+// display controller used by the LCD bricklets.
+// The mapping follows the one of the official Tinkerforge bindings.
+package ks0066u
+
+// special maps unicode code points outside of the ASCII and Katakana ranges
+var special = map[rune]byte{
+	0x00a5: 0x5c, // YEN SIGN
+	0x2192: 0x7e, // RIGHTWARDS ARROW
+	0x2190: 0x7f, // LEFTWARDS ARROW
+	0x00b0: 0xdf, // DEGREE SIGN maps to KATAKANA SEMI-VOICED SOUND MARK
+	0x03b1: 0xe0, // GREEK SMALL LETTER ALPHA
+	0x00c4: 0xe1, // LATIN CAPITAL LETTER A WITH DIAERESIS
+	0x00e4: 0xe1, // LATIN SMALL LETTER A WITH DIAERESIS
+	0x00df: 0xe2, // LATIN SMALL LETTER SHARP S
+	0x03b5: 0xe3, // GREEK SMALL LETTER EPSILON
+	0x00b5: 0xe4, // MICRO SIGN
+	0x03bc: 0xe4, // GREEK SMALL LETTER MU
+	0x03c2: 0xe5, // GREEK SMALL LETTER FINAL SIGMA
+	0x03c1: 0xe6, // GREEK SMALL LETTER RHO
+	0x221a: 0xe8, // SQUARE ROOT
+	0x00b9: 0xe9, // SUPERSCRIPT ONE maps to SUPERSCRIPT (minus) ONE
+	0x00a4: 0xeb, // CURRENCY SIGN
+	0x00a2: 0xec, // CENT SIGN
+	0x2c60: 0xed, // LATIN CAPITAL LETTER L WITH DOUBLE BAR
+	0x00f1: 0xee, // LATIN SMALL LETTER N WITH TILDE
+	0x00d6: 0xef, // LATIN CAPITAL LETTER O WITH DIAERESIS
+	0x00f6: 0xef, // LATIN SMALL LETTER O WITH DIAERESIS
+	0x03f4: 0xf2, // GREEK CAPITAL THETA SYMBOL
+	0x221e: 0xf3, // INFINITY
+	0x03a9: 0xf4, // GREEK CAPITAL LETTER OMEGA
+	0x00dc: 0xf5, // LATIN CAPITAL LETTER U WITH DIAERESIS
+	0x00fc: 0xf5, // LATIN SMALL LETTER U WITH DIAERESIS
+	0x03a3: 0xf6, // GREEK CAPITAL LETTER SIGMA
+	0x03c0: 0xf7, // GREEK SMALL LETTER PI
+	0x0304: 0xf8, // COMBINING MACRON
+	0x00f7: 0xfd, // DIVISION SIGN
+	0x25a0: 0xff, // BLACK SQUARE
+}
+
+const (
+	macron      = 0xf8
+	blackSquare = 0xff
+)
+
+// FromString converts 'text' into the KS0066U character set.
+// Characters without a representation are replaced by a black square.
+func FromString(text string) []byte {
+	result := make([]byte, 0, len(text))
+
+	for _, r := range text {
+		var c byte
+
+		switch {
+		case r == '\\':
+			// The display has no backslash, use the ideographic comma instead
+			c = 0xa4
+		case r == '~':
+			// The display has no tilde, use a hyphen instead
+			c = '-'
+		case r >= 0x20 && r <= 0x7e:
+			// ASCII subset of JIS X 0201
+			c = byte(r)
+		case r >= 0xff61 && r <= 0xff9f:
+			// Katakana subset of JIS X 0201
+			c = byte(r - 0xfec0)
+		default:
+			mapped, ok := special[r]
+			if !ok {
+				mapped = blackSquare
+			}
+			c = mapped
+		}
+
+		// The macron is only available as x-bar, replacing the preceding 'x'
+		if c == macron {
+			if len(result) == 0 || result[len(result)-1] != 'x' {
+				c = blackSquare
+			} else {
+				result = result[:len(result)-1]
+			}
+		}
+
+		result = append(result, c)
+	}
+
+	return result
+}
@@ -0,0 +1,31 @@
+package ks0066u
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFromString(t *testing.T) {
+	tests := []struct {
+		text string
+		want []byte
+	}{
+		{"", []byte{}},
+		{"Hello 42!", []byte("Hello 42!")},
+		{`a\b~c`, []byte{'a', 0xa4, 'b', '-', 'c'}},
+		{"ｱｲｳ", []byte{0xb1, 0xb2, 0xb3}},
+		{"20°C", []byte{'2', '0', 0xdf, 'C'}},
+		{"ÄäÖöÜüß", []byte{0xe1, 0xe1, 0xef, 0xef, 0xf5, 0xf5, 0xe2}},
+		{"¥→←π∞Ω", []byte{0x5c, 0x7e, 0x7f, 0xf7, 0xf3, 0xf4}},
+		{"x\u0304", []byte{0xf8}},
+		{"a\u0304", []byte{'a', 0xff}},
+		{"\u0304", []byte{0xff}},
+		{"€\t☃", []byte{0xff, 0xff, 0xff}},
+	}
+
+	for _, test := range tests {
+		if got := FromString(test.text); !bytes.Equal(got, test.want) {
+			t.Errorf("FromString(%q) = % x, want % x", test.text, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+// Package io16 has control routines for the IO-16 Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package io16
+
+import (
+	"errors"
+
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// IO16 is a control structure for IO-16 Bricklets
+type IO16 struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+const (
+	// PortA selects the first 8 pins
+	PortA byte = 'a'
+	// PortB selects the second 8 pins
+	PortB byte = 'b'
+
+	// DirectionIn configures pins as inputs
+	DirectionIn byte = 'i'
+	// DirectionOut configures pins as outputs
+	DirectionOut byte = 'o'
+)
+
+var (
+	// ErrInvalidPin is returned for a pin number outside of 0 to 7
+	ErrInvalidPin = errors.New("Invalid pin")
+)
+
+// New creates a new IO-16 control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*IO16, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &IO16{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// SetPort sets the output value of the pins of 'port' to the bits of 'valueMask'.
+// For pins configured as input a set bit enables the pull-up resistor.
+func (i *IO16) SetPort(port, valueMask byte) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 1, false, port, valueMask)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = i.t.Send(p)
+	return err
+}
+
+// SetSelectedValues sets the output value of the pins of 'port' selected by 'selectionMask'
+// to the bits of 'valueMask', leaving the other pins untouched.
+func (i *IO16) SetSelectedValues(port, selectionMask, valueMask byte) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 13, false, port, selectionMask, valueMask)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = i.t.Send(p)
+	return err
+}
+
+// GetPort returns the current value of the pins of 'port' as a bitmask.
+func (i *IO16) GetPort(port byte) (byte, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 2, true, port)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := i.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the value mask
+	var valueMask byte
+	if err = res.Decode(&valueMask); err != nil {
+		return 0, err
+	}
+
+	return valueMask, nil
+}
+
+// SetPortConfiguration configures the pins of 'port' selected by 'selectionMask'.
+// 'direction' is DirectionIn or DirectionOut, 'value' is the output value or the pull-up state.
+func (i *IO16) SetPortConfiguration(port, selectionMask, direction byte, value bool) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 3, false, port, selectionMask, direction, value)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = i.t.Send(p)
+	return err
+}
+
+// SetDebouncePeriod sets the debounce period of the interrupt callback in ms.
+func (i *IO16) SetDebouncePeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 5, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = i.t.Send(p)
+	return err
+}
+
+// SetPortInterrupt enables the interrupt callback for the pins of 'port' set in 'interruptMask'.
+func (i *IO16) SetPortInterrupt(port, interruptMask byte) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 7, false, port, interruptMask)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = i.t.Send(p)
+	return err
+}
+
+// SetPin sets a single pin of 'port' high or low, leaving the other pins untouched.
+func (i *IO16) SetPin(port byte, pin uint8, high bool) error {
+	if pin > 7 {
+		return ErrInvalidPin
+	}
+
+	// Only the pin is selected, the bricklet keeps the other pins
+	var valueMask byte
+	if high {
+		valueMask = 1 << pin
+	}
+
+	return i.SetSelectedValues(port, 1<<pin, valueMask)
+}
+
+// GetPin returns whether a single pin of 'port' is high.
+func (i *IO16) GetPin(port byte, pin uint8) (bool, error) {
+	if pin > 7 {
+		return false, ErrInvalidPin
+	}
+
+	valueMask, err := i.GetPort(port)
+	if err != nil {
+		return false, err
+	}
+
+	return valueMask&(1<<pin) != 0, nil
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (i *IO16) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	id, err := helpers.GetIdentity(i.t, i.uid)
+	return id, err
+}
+
+type interruptHandler func(byte, byte, byte)
+
+func (f interruptHandler) Handle(p *tinkerforge.Packet) {
+
+	var (
+		port          byte
+		interruptMask byte
+		valueMask     byte
+	)
+
+	if p.Decode(&port, &interruptMask, &valueMask) != nil {
+		return
+	}
+	f(port, interruptMask, valueMask)
+
+}
+
+// CallbackInterrupt is a convenience function for registering
+// a handler to be called when a pin enabled by SetPortInterrupt changes.
+func (i *IO16) CallbackInterrupt(handler func(port byte, interruptMask, valueMask byte)) {
+
+	if handler == nil {
+		i.callbacks.Set(i.t, i.uid, 9, nil)
+	} else {
+		i.callbacks.Set(i.t, i.uid, 9, interruptHandler(handler))
+	}
+
+}
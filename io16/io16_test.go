@@ -0,0 +1,41 @@
+package io16
+
+import (
+	"testing"
+
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+func TestWire(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	io, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.Expect(t, io.uid, []tinkerforgetest.Expectation{
+		{Name: "SetPort", Call: func() error { return io.SetPort('a', 0x0f) }, FuncID: 1, Payload: []byte{'a', 0x0f}},
+		{Name: "SetSelectedValues", Call: func() error { return io.SetSelectedValues('b', 0x03, 0x01) }, FuncID: 13, Payload: []byte{'b', 0x03, 0x01}},
+		{Name: "SetPin high", Call: func() error { return io.SetPin('a', 3, true) }, FuncID: 13, Payload: []byte{'a', 0x08, 0x08}},
+		{Name: "SetPin low", Call: func() error { return io.SetPin('b', 7, false) }, FuncID: 13, Payload: []byte{'b', 0x80, 0x00}},
+	})
+}
+
+func TestSetPinInvalid(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	io, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := io.SetPin('a', 8, true); err != ErrInvalidPin {
+		t.Errorf("SetPin() error = %v, want ErrInvalidPin", err)
+	}
+	if len(m.Sent()) != 0 {
+		t.Errorf("sent %d packets, want 0", len(m.Sent()))
+	}
+}
@@ -0,0 +1,197 @@
+// Package io4 has control routines for the IO-4 Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package io4
+
+import (
+	"errors"
+
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// IO4 is a control structure for IO-4 Bricklets
+type IO4 struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+const (
+	// DirectionIn configures pins as inputs
+	DirectionIn byte = 'i'
+	// DirectionOut configures pins as outputs
+	DirectionOut byte = 'o'
+
+	// PinMask covers the four pins of the bricklet
+	PinMask uint8 = 0x0f
+)
+
+var (
+	// ErrInvalidMask is returned for a bitmask with bits set above the fourth pin
+	ErrInvalidMask = errors.New("Invalid pin mask")
+)
+
+// New creates a new IO-4 control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*IO4, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &IO4{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// checkMasks validates that the masks only address the four pins of the bricklet.
+func checkMasks(masks ...uint8) error {
+	for _, m := range masks {
+		if m&^PinMask != 0 {
+			return ErrInvalidMask
+		}
+	}
+
+	return nil
+}
+
+// SetValue sets the output value of all pins to the bits of 'valueMask'.
+// For pins configured as input a set bit enables the pull-up resistor.
+func (i *IO4) SetValue(valueMask uint8) error {
+	if err := checkMasks(valueMask); err != nil {
+		return err
+	}
+
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 1, false, valueMask)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = i.t.Send(p)
+	return err
+}
+
+// GetValue returns the current value of the pins as a bitmask.
+func (i *IO4) GetValue() (uint8, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 2, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := i.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the value mask
+	var valueMask uint8
+	if err = res.Decode(&valueMask); err != nil {
+		return 0, err
+	}
+
+	return valueMask, nil
+}
+
+// SetSelectedValues sets the output value of the pins selected by 'selectionMask'
+// to the bits of 'valueMask', leaving the other pins untouched.
+func (i *IO4) SetSelectedValues(selectionMask, valueMask uint8) error {
+	if err := checkMasks(selectionMask, valueMask); err != nil {
+		return err
+	}
+
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 13, false, selectionMask, valueMask)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = i.t.Send(p)
+	return err
+}
+
+// SetConfiguration configures the pins selected by 'selectionMask'.
+// 'direction' is DirectionIn or DirectionOut, 'value' is the output value or the pull-up state.
+func (i *IO4) SetConfiguration(selectionMask uint8, direction byte, value bool) error {
+	if err := checkMasks(selectionMask); err != nil {
+		return err
+	}
+
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 3, false, selectionMask, direction, value)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = i.t.Send(p)
+	return err
+}
+
+// SetDebouncePeriod sets the debounce period of the interrupt callback in ms.
+func (i *IO4) SetDebouncePeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 5, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = i.t.Send(p)
+	return err
+}
+
+// SetInterrupt enables the interrupt callback for the pins set in 'interruptMask'.
+func (i *IO4) SetInterrupt(interruptMask uint8) error {
+	if err := checkMasks(interruptMask); err != nil {
+		return err
+	}
+
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(i.uid, 7, false, interruptMask)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = i.t.Send(p)
+	return err
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (i *IO4) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	id, err := helpers.GetIdentity(i.t, i.uid)
+	return id, err
+}
+
+type interruptHandler func(uint8, uint8)
+
+func (f interruptHandler) Handle(p *tinkerforge.Packet) {
+
+	var (
+		interruptMask uint8
+		valueMask     uint8
+	)
+
+	if p.Decode(&interruptMask, &valueMask) != nil {
+		return
+	}
+	f(interruptMask, valueMask)
+
+}
+
+// CallbackInterrupt is a convenience function for registering
+// a handler to be called when a pin enabled by SetInterrupt changes.
+func (i *IO4) CallbackInterrupt(handler func(interruptMask, valueMask uint8)) {
+
+	if handler == nil {
+		i.callbacks.Set(i.t, i.uid, 9, nil)
+	} else {
+		i.callbacks.Set(i.t, i.uid, 9, interruptHandler(handler))
+	}
+
+}
@@ -0,0 +1,91 @@
+package io4
+
+import (
+	"testing"
+	"time"
+
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+func TestWire(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	io, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.Expect(t, io.uid, []tinkerforgetest.Expectation{
+		{Name: "SetValue", Call: func() error { return io.SetValue(0x05) }, FuncID: 1, Payload: []byte{0x05}},
+		{Name: "SetConfiguration", Call: func() error { return io.SetConfiguration(0x03, DirectionOut, true) }, FuncID: 3, Payload: []byte{0x03, 'o', 1}},
+		{Name: "SetDebouncePeriod", Call: func() error { return io.SetDebouncePeriod(100) }, FuncID: 5, Payload: []byte{100, 0, 0, 0}},
+		{Name: "SetInterrupt", Call: func() error { return io.SetInterrupt(0x08) }, FuncID: 7, Payload: []byte{0x08}},
+		{Name: "SetSelectedValues", Call: func() error { return io.SetSelectedValues(0x03, 0x01) }, FuncID: 13, Payload: []byte{0x03, 0x01}},
+	})
+}
+
+func TestInvalidMask(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	io, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := io.SetSelectedValues(0x10, 0x00); err != ErrInvalidMask {
+		t.Errorf("SetSelectedValues(0x10, 0x00) = %v, want ErrInvalidMask", err)
+	}
+	if len(m.Sent()) != 0 {
+		t.Errorf("invalid mask was sent: %v", m.Sent())
+	}
+}
+
+func TestGetValue(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	io, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Respond(io.uid, 2, uint8(0x0a)); err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := io.GetValue()
+	if err != nil || value != 0x0a {
+		t.Errorf("GetValue() = %#x, %v, want 0xa", value, err)
+	}
+}
+
+func TestCallbackInterrupt(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	io, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type interrupt struct{ interruptMask, valueMask uint8 }
+	got := make(chan interrupt, 1)
+	io.CallbackInterrupt(func(interruptMask, valueMask uint8) {
+		got <- interrupt{interruptMask, valueMask}
+	})
+
+	if err := m.Fire(io.uid, 9, uint8(0x02), uint8(0x06)); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case i := <-got:
+		if i != (interrupt{0x02, 0x06}) {
+			t.Errorf("got interrupt %+v, want {0x2 0x6}", i)
+		}
+	case <-time.After(time.Second):
+		t.Error("interrupt callback was not called")
+	}
+}
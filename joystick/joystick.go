@@ -0,0 +1,187 @@
+// Package joystick has control routines for the Joystick Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package joystick
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// Joystick is a control structure for Joystick Bricklets
+type Joystick struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+// New creates a new joystick control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*Joystick, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &Joystick{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// GetPosition returns the position of the joystick (-100 to 100 on both axes, 0 is the middle position).
+func (j *Joystick) GetPosition() (x, y int16, err error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(j.uid, 1, true)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Send the packet
+	res, err := j.t.Send(p)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Decode the position
+	if err = res.Decode(&x, &y); err != nil {
+		return 0, 0, err
+	}
+
+	return x, y, nil
+}
+
+// IsPressed returns whether the button of the joystick is pressed.
+func (j *Joystick) IsPressed() (bool, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(j.uid, 2, true)
+	if err != nil {
+		return false, err
+	}
+
+	// Send the packet
+	res, err := j.t.Send(p)
+	if err != nil {
+		return false, err
+	}
+
+	// Decode the button state
+	var pressed bool
+	if err = res.Decode(&pressed); err != nil {
+		return false, err
+	}
+
+	return pressed, nil
+}
+
+// GetAnalogValue returns the raw values of the analog-digital converters (0 to 4095) for both axes.
+func (j *Joystick) GetAnalogValue() (x, y uint16, err error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(j.uid, 3, true)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Send the packet
+	res, err := j.t.Send(p)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Decode the values
+	if err = res.Decode(&x, &y); err != nil {
+		return 0, 0, err
+	}
+
+	return x, y, nil
+}
+
+// Calibrate calibrates the middle position of the joystick. Don't touch the joystick while calibrating.
+func (j *Joystick) Calibrate() error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(j.uid, 4, false)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = j.t.Send(p)
+	return err
+}
+
+// SetPositionCallbackPeriod sets the period in ms with which the position callback is triggered.
+// The callback is only triggered if the position changed. A value of 0 turns the callback off.
+func (j *Joystick) SetPositionCallbackPeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(j.uid, 5, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = j.t.Send(p)
+	return err
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (j *Joystick) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(j.t, j.uid)
+	return i, err
+}
+
+type positionHandler func(int16, int16)
+
+func (f positionHandler) Handle(p *tinkerforge.Packet) {
+
+	var (
+		x int16
+		y int16
+	)
+
+	if p.Decode(&x, &y) != nil {
+		return
+	}
+	f(x, y)
+
+}
+
+// CallbackPosition is a convenience function for registering
+// a handler to be called periodically with the position.
+// The period is set with SetPositionCallbackPeriod.
+func (j *Joystick) CallbackPosition(handler func(x, y int16)) {
+
+	if handler == nil {
+		j.callbacks.Set(j.t, j.uid, 15, nil)
+	} else {
+		j.callbacks.Set(j.t, j.uid, 15, positionHandler(handler))
+	}
+
+}
+
+type buttonHandler func()
+
+func (f buttonHandler) Handle(p *tinkerforge.Packet) {
+	f()
+}
+
+// CallbackPressed is a convenience function for registering
+// a handler to be called when the button is pressed.
+func (j *Joystick) CallbackPressed(handler func()) {
+
+	if handler == nil {
+		j.callbacks.Set(j.t, j.uid, 19, nil)
+	} else {
+		j.callbacks.Set(j.t, j.uid, 19, buttonHandler(handler))
+	}
+
+}
+
+// CallbackReleased is a convenience function for registering
+// a handler to be called when the button is released.
+func (j *Joystick) CallbackReleased(handler func()) {
+
+	if handler == nil {
+		j.callbacks.Set(j.t, j.uid, 20, nil)
+	} else {
+		j.callbacks.Set(j.t, j.uid, 20, buttonHandler(handler))
+	}
+
+}
@@ -0,0 +1,172 @@
+// Package lcd20x4 has control routines for the LCD 20x4 Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package lcd20x4
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+	"github.com/noxer/tinkerforge/internal/ks0066u"
+)
+
+// LCD20x4 is a control structure for LCD 20x4 Bricklets
+type LCD20x4 struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+const (
+	// Columns is the number of characters per line
+	Columns = 20
+	// Lines is the number of lines of the display
+	Lines = 4
+)
+
+// New creates a new LCD 20x4 control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*LCD20x4, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &LCD20x4{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// WriteLine writes 'text' to 'line' (0 to 3) beginning at 'position' (0 to 19).
+// The text is converted to the display's character set, text longer than 20 characters is truncated.
+func (l *LCD20x4) WriteLine(line, position uint8, text string) error {
+	// Pack the text into a fixed size, zero padded array
+	var data [Columns]byte
+	tinkerforge.WriteString(data[:], string(ks0066u.FromString(text)))
+
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(l.uid, 1, false, line, position, data)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = l.t.Send(p)
+	return err
+}
+
+// ClearDisplay deletes all characters from the display.
+func (l *LCD20x4) ClearDisplay() error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(l.uid, 2, false)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = l.t.Send(p)
+	return err
+}
+
+// BacklightOn turns the backlight on.
+func (l *LCD20x4) BacklightOn() error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(l.uid, 3, false)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = l.t.Send(p)
+	return err
+}
+
+// BacklightOff turns the backlight off.
+func (l *LCD20x4) BacklightOff() error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(l.uid, 4, false)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = l.t.Send(p)
+	return err
+}
+
+// IsBacklightOn returns whether the backlight is on.
+func (l *LCD20x4) IsBacklightOn() (bool, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(l.uid, 5, true)
+	if err != nil {
+		return false, err
+	}
+
+	// Send the packet
+	res, err := l.t.Send(p)
+	if err != nil {
+		return false, err
+	}
+
+	// Decode the backlight state
+	var on bool
+	if err = res.Decode(&on); err != nil {
+		return false, err
+	}
+
+	return on, nil
+}
+
+// SetConfig configures whether the cursor is shown and whether it is blinking.
+func (l *LCD20x4) SetConfig(cursor, blinking bool) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(l.uid, 6, false, cursor, blinking)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = l.t.Send(p)
+	return err
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (l *LCD20x4) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(l.t, l.uid)
+	return i, err
+}
+
+type buttonHandler func(uint8)
+
+func (f buttonHandler) Handle(p *tinkerforge.Packet) {
+
+	var button uint8
+
+	if p.Decode(&button) != nil {
+		return
+	}
+	f(button)
+
+}
+
+// CallbackButtonPressed is a convenience function for registering
+// a handler to be called when a button (0 to 3) is pressed.
+func (l *LCD20x4) CallbackButtonPressed(handler func(button uint8)) {
+
+	if handler == nil {
+		l.callbacks.Set(l.t, l.uid, 9, nil)
+	} else {
+		l.callbacks.Set(l.t, l.uid, 9, buttonHandler(handler))
+	}
+
+}
+
+// CallbackButtonReleased is a convenience function for registering
+// a handler to be called when a button (0 to 3) is released.
+func (l *LCD20x4) CallbackButtonReleased(handler func(button uint8)) {
+
+	if handler == nil {
+		l.callbacks.Set(l.t, l.uid, 10, nil)
+	} else {
+		l.callbacks.Set(l.t, l.uid, 10, buttonHandler(handler))
+	}
+
+}
@@ -0,0 +1,33 @@
+package lcd20x4
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+// payload returns the payload of WriteLine for 'line' and 'position' with 'text' padded to Columns
+func payload(line, position uint8, text string) []byte {
+	data := make([]byte, Columns)
+	copy(data, text)
+	return append([]byte{line, position}, data...)
+}
+
+func TestWriteLine(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	l, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	long := strings.Repeat("0123456789", 3)
+
+	m.Expect(t, l.uid, []tinkerforgetest.Expectation{
+		{Name: "WriteLine short", Call: func() error { return l.WriteLine(1, 2, "Hi") }, FuncID: 1, Payload: payload(1, 2, "Hi")},
+		{Name: "WriteLine long", Call: func() error { return l.WriteLine(0, 0, long) }, FuncID: 1, Payload: payload(0, 0, long[:Columns])},
+		{Name: "WriteLine converted", Call: func() error { return l.WriteLine(0, 0, "20°C") }, FuncID: 1, Payload: payload(0, 0, "20\xdfC")},
+	})
+}
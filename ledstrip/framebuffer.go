@@ -0,0 +1,109 @@
+package ledstrip
+
+// Framebuffer holds the colors of a whole LED strip and only sends
+// the parts that changed since the last flush.
+type Framebuffer struct {
+	colors  []Color
+	flushed []Color
+	valid   bool
+}
+
+// NewFramebuffer creates a new framebuffer for 'length' LEDs, all turned off.
+func NewFramebuffer(length int) *Framebuffer {
+	return &Framebuffer{
+		colors:  make([]Color, length),
+		flushed: make([]Color, length),
+	}
+}
+
+// Len returns the number of LEDs in the framebuffer.
+func (f *Framebuffer) Len() int {
+	return len(f.colors)
+}
+
+// Get returns the color of LED 'i'. LEDs outside of the framebuffer are black.
+func (f *Framebuffer) Get(i int) Color {
+	if i < 0 || i >= len(f.colors) {
+		return Color{}
+	}
+
+	return f.colors[i]
+}
+
+// Set sets LED 'i' to the color 'c'. LEDs outside of the framebuffer are ignored.
+func (f *Framebuffer) Set(i int, c Color) {
+	if i < 0 || i >= len(f.colors) {
+		return
+	}
+
+	f.colors[i] = c
+}
+
+// Clear turns all LEDs off.
+func (f *Framebuffer) Clear() {
+	for i := range f.colors {
+		f.colors[i] = Color{}
+	}
+}
+
+// Shift moves all colors by 'n' LEDs towards the end of the strip (or towards the start if 'n' is negative).
+// LEDs shifted out at one end of the strip come back in at the other end.
+func (f *Framebuffer) Shift(n int) {
+	if len(f.colors) == 0 {
+		return
+	}
+
+	// Shifting by -n equals shifting by len-n
+	n %= len(f.colors)
+	if n < 0 {
+		n += len(f.colors)
+	}
+
+	// Rotate in place by reversing the whole strip and both parts
+	reverseColors(f.colors)
+	reverseColors(f.colors[:n])
+	reverseColors(f.colors[n:])
+}
+
+// Flush sends the chunks of MaxLEDsPerFrame LEDs that changed since the last flush to 'l'.
+// The first flush sends the whole framebuffer.
+func (f *Framebuffer) Flush(l *LedStrip) error {
+	for start := 0; start < len(f.colors); start += MaxLEDsPerFrame {
+		end := min(start+MaxLEDsPerFrame, len(f.colors))
+
+		// Skip unchanged chunks
+		if f.valid && equalColors(f.colors[start:end], f.flushed[start:end]) {
+			continue
+		}
+
+		if err := l.SetRGBValues(uint16(start), f.colors[start:end]); err != nil {
+			// Resend everything next time, the strip state is unknown
+			f.valid = false
+			return err
+		}
+
+		// Remember what the strip shows now
+		copy(f.flushed[start:end], f.colors[start:end])
+	}
+
+	f.valid = true
+	return nil
+}
+
+// reverseColors reverses the order of the colors in c.
+func reverseColors(c []Color) {
+	for i, j := 0, len(c)-1; i < j; i, j = i+1, j-1 {
+		c[i], c[j] = c[j], c[i]
+	}
+}
+
+// equalColors compares two color slices of the same length.
+func equalColors(a, b []Color) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,118 @@
+package ledstrip
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+// chunk is the index and LED count of a sent SetRGBValues packet
+type chunk struct {
+	index  uint16
+	length uint8
+}
+
+// flush flushes f to l and returns the chunks it sent
+func flush(t *testing.T, m *tinkerforgetest.Mock, f *Framebuffer, l *LedStrip, want int) []chunk {
+	t.Helper()
+
+	before := len(m.Sent())
+	if err := f.Flush(l); err != nil {
+		t.Fatal(err)
+	}
+
+	sent, err := m.WaitSent(before+want, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Make sure nothing else follows
+	time.Sleep(20 * time.Millisecond)
+	sent = m.Sent()
+
+	var chunks []chunk
+	for _, p := range sent[before:] {
+		chunks = append(chunks, chunk{binary.LittleEndian.Uint16(p.Payload()), p.Payload()[2]})
+	}
+	return chunks
+}
+
+func TestFramebufferFlush(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	l, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 40 LEDs are two full chunks and a partial one
+	f := NewFramebuffer(40)
+
+	// The first flush sends everything
+	if got, want := flush(t, m, f, l, 3), []chunk{{0, 16}, {16, 16}, {32, 8}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("first Flush() sent %v, want %v", got, want)
+	}
+
+	// Nothing changed
+	if got := flush(t, m, f, l, 0); len(got) != 0 {
+		t.Errorf("second Flush() sent %v, want nothing", got)
+	}
+
+	// Only the chunks with changes are sent
+	f.Set(3, Color{1, 2, 3})
+	f.Set(39, Color{4, 5, 6})
+	f.Set(40, Color{7, 8, 9}) // outside, ignored
+	if got, want := flush(t, m, f, l, 2), []chunk{{0, 16}, {32, 8}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Flush() after Set sent %v, want %v", got, want)
+	}
+
+	// The partial chunk carries the colors of its LEDs
+	last := m.Sent()[len(m.Sent())-1].Payload()
+	if last[3+7] != 4 || last[3+16+7] != 5 || last[3+32+7] != 6 {
+		t.Errorf("partial chunk payload % x, want color 04 05 06 for LED 39", last)
+	}
+
+	// Clear changes the chunks that were set
+	f.Clear()
+	if got, want := flush(t, m, f, l, 2), []chunk{{0, 16}, {32, 8}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Flush() after Clear sent %v, want %v", got, want)
+	}
+}
+
+func TestFramebufferShift(t *testing.T) {
+	f := NewFramebuffer(5)
+	for i := 0; i < f.Len(); i++ {
+		f.Set(i, Color{byte(i)})
+	}
+
+	colors := func() []byte {
+		var c []byte
+		for i := 0; i < f.Len(); i++ {
+			c = append(c, f.Get(i)[0])
+		}
+		return c
+	}
+
+	tests := []struct {
+		n    int
+		want []byte
+	}{
+		{2, []byte{3, 4, 0, 1, 2}},
+		{-2, []byte{0, 1, 2, 3, 4}},
+		{-1, []byte{1, 2, 3, 4, 0}},
+		{6, []byte{0, 1, 2, 3, 4}},
+		{5, []byte{0, 1, 2, 3, 4}},
+		{-7, []byte{2, 3, 4, 0, 1}},
+	}
+
+	for _, test := range tests {
+		f.Shift(test.n)
+		if got := colors(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Shift(%d) = %v, want %v", test.n, got, test.want)
+		}
+	}
+}
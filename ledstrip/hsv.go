@@ -0,0 +1,112 @@
+package ledstrip
+
+import (
+	"math"
+)
+
+// HSVColor represents a color by hue (0 to 360), saturation (0 to 1) and value (0 to 1).
+type HSVColor struct {
+	H, S, V float64
+}
+
+// ColorFromHSV converts hue (0 to 360), saturation (0 to 1) and value (0 to 1) into an RGB color.
+func ColorFromHSV(h, s, v float64) Color {
+	// Normalize the parameters
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	s = clamp(s)
+	v = clamp(v)
+
+	// Calculate the position on the color wheel
+	c := v * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := v - c
+
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+
+	return Color{toByte(r + m), toByte(g + m), toByte(b + m)}
+}
+
+// HSV converts the color into hue (0 to 360), saturation (0 to 1) and value (0 to 1).
+func (c Color) HSV() (h, s, v float64) {
+	r, g, b := float64(c[0])/255, float64(c[1])/255, float64(c[2])/255
+
+	max := math.Max(r, math.Max(g, b))
+	min := math.Min(r, math.Min(g, b))
+	delta := max - min
+
+	// Value and saturation
+	v = max
+	if max > 0 {
+		s = delta / max
+	}
+
+	// Hue (undefined for grey, we use 0)
+	switch {
+	case delta == 0:
+		h = 0
+	case max == r:
+		h = 60 * math.Mod((g-b)/delta, 6)
+	case max == g:
+		h = 60 * ((b-r)/delta + 2)
+	default:
+		h = 60 * ((r-g)/delta + 4)
+	}
+	if h < 0 {
+		h += 360
+	}
+
+	return h, s, v
+}
+
+// Color converts the HSV color into an RGB color.
+func (c HSVColor) Color() Color {
+	return ColorFromHSV(c.H, c.S, c.V)
+}
+
+// GammaCorrect applies gamma correction to all channels of the color.
+// A gamma of about 2.2 makes WS2812 LEDs look more natural.
+func (c Color) GammaCorrect(gamma float64) Color {
+	var result Color
+	for i, v := range c {
+		result[i] = toByte(math.Pow(float64(v)/255, gamma))
+	}
+
+	return result
+}
+
+// SetAllHSV converts 'colors' to RGB and sets them beginning from 'index'.
+func (l *LedStrip) SetAllHSV(index uint16, colors []HSVColor) error {
+	rgb := make([]Color, len(colors))
+	for i, c := range colors {
+		rgb[i] = c.Color()
+	}
+
+	return l.SetAllRGBValues(index, rgb)
+}
+
+// clamp limits f to the range 0 to 1.
+func clamp(f float64) float64 {
+	return math.Max(0, math.Min(1, f))
+}
+
+// toByte converts a value of 0 to 1 into a byte of 0 to 255.
+func toByte(f float64) byte {
+	return byte(math.Round(clamp(f) * 255))
+}
@@ -0,0 +1,109 @@
+package ledstrip
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+func TestColorFromHSV(t *testing.T) {
+	tests := []struct {
+		h, s, v float64
+		want    Color
+	}{
+		{0, 1, 1, Color{255, 0, 0}},
+		{60, 1, 1, Color{255, 255, 0}},
+		{120, 1, 1, Color{0, 255, 0}},
+		{180, 1, 1, Color{0, 255, 255}},
+		{240, 1, 1, Color{0, 0, 255}},
+		{300, 1, 1, Color{255, 0, 255}},
+		{30, 1, 1, Color{255, 128, 0}},
+		{0, 0, 0.5, Color{128, 128, 128}},
+		{-120, 1, 1, Color{0, 0, 255}},
+		{480, 1, 1, Color{0, 255, 0}},
+		{0, 2, -1, Color{0, 0, 0}},
+	}
+
+	for _, test := range tests {
+		if got := ColorFromHSV(test.h, test.s, test.v); got != test.want {
+			t.Errorf("ColorFromHSV(%v, %v, %v) = %v, want %v", test.h, test.s, test.v, got, test.want)
+		}
+	}
+}
+
+func TestHSV(t *testing.T) {
+	tests := []struct {
+		c       Color
+		h, s, v float64
+	}{
+		{Color{255, 0, 0}, 0, 1, 1},
+		{Color{0, 255, 0}, 120, 1, 1},
+		{Color{0, 0, 255}, 240, 1, 1},
+		{Color{255, 0, 255}, 300, 1, 1},
+		{Color{0, 0, 0}, 0, 0, 0},
+		{Color{255, 255, 255}, 0, 0, 1},
+	}
+
+	for _, test := range tests {
+		h, s, v := test.c.HSV()
+		if math.Abs(h-test.h) > 1e-9 || math.Abs(s-test.s) > 1e-9 || math.Abs(v-test.v) > 1e-9 {
+			t.Errorf("%v.HSV() = %v, %v, %v, want %v, %v, %v", test.c, h, s, v, test.h, test.s, test.v)
+		}
+
+		// Converting back must give the same color
+		if got := ColorFromHSV(h, s, v); got != test.c {
+			t.Errorf("ColorFromHSV(%v.HSV()) = %v", test.c, got)
+		}
+	}
+}
+
+func TestGammaCorrect(t *testing.T) {
+	tests := []struct {
+		c     Color
+		gamma float64
+		want  Color
+	}{
+		{Color{0, 128, 255}, 1, Color{0, 128, 255}},
+		{Color{0, 128, 255}, 2.2, Color{0, 56, 255}},
+		{Color{64, 192, 255}, 2, Color{16, 145, 255}},
+	}
+
+	for _, test := range tests {
+		if got := test.c.GammaCorrect(test.gamma); got != test.want {
+			t.Errorf("%v.GammaCorrect(%v) = %v, want %v", test.c, test.gamma, got, test.want)
+		}
+	}
+}
+
+func TestSetAllHSV(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	l, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := l.SetAllHSV(5, []HSVColor{{0, 1, 1}, {120, 1, 1}}); err != nil {
+		t.Fatal(err)
+	}
+
+	sent, err := m.WaitSent(1, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// index, length, 16 red, 16 green and 16 blue values
+	want := make([]byte, 3+3*MaxLEDsPerFrame)
+	want[0], want[2] = 5, 2
+	want[3] = 255
+	want[3+MaxLEDsPerFrame+1] = 255
+
+	p := sent[0]
+	if p.UID() != l.uid || p.FunctionID() != 1 || !bytes.Equal(p.Payload(), want) {
+		t.Errorf("got %v, want function ID 1 and payload % x", p, want)
+	}
+}
@@ -3,6 +3,8 @@
 package ledstrip
 
 import (
+	"fmt"
+
 	"github.com/noxer/tinkerforge"
 	"github.com/noxer/tinkerforge/helpers"
 )
@@ -11,8 +13,10 @@ import (
 type LedStrip struct {
 	t           tinkerforge.Tinkerforge
 	uid         uint32
+	callbacks   helpers.Callbacks
 	colorMap    [3]int
 	revColorMap [3]int
+	length      int
 }
 
 // Color represents a three byte value (8 bit for red, green and blue respectively).
@@ -25,6 +29,13 @@ var (
 	ColorMapBGR = [3]int{2, 1, 0}
 )
 
+const (
+	// MaxLEDsPerFrame is the maximum number of LEDs the protocol can set or get in one packet
+	MaxLEDsPerFrame = 16
+	// MaxStripLength is the maximum number of LEDs the bricklet can drive
+	MaxStripLength = 320
+)
+
 // ChipType represents different types of control chips.
 type ChipType uint16
 
@@ -48,19 +59,63 @@ func New(t tinkerforge.Tinkerforge, uid string) (*LedStrip, error) {
 		uid:         readUID,
 		colorMap:    [3]int{0, 1, 2},
 		revColorMap: [3]int{0, 1, 2},
+		length:      MaxStripLength,
 	}, nil
 }
 
 // SetAllRGBValues sets all color values beginning from 'index' to the values in 'colors'.
 func (l *LedStrip) SetAllRGBValues(index uint16, colors []Color) error {
+	// Check the bounds before sending anything
+	if err := l.checkBounds(index, len(colors)); err != nil {
+		return err
+	}
+
 	for len(colors) > 0 {
 		if err := l.SetRGBValues(index, colors); err != nil {
 			return err
 		}
 
 		// calculate the remaining slice
-		colors = colors[min(len(colors), 16):]
-		index += 16
+		colors = colors[min(len(colors), MaxLEDsPerFrame):]
+		index += MaxLEDsPerFrame
+	}
+
+	return nil
+}
+
+// FillColor sets the first 'count' LEDs of the strip to the color 'c'.
+func (l *LedStrip) FillColor(count int, c Color) error {
+	// Check the count before allocating, make panics on negative values
+	if count < 0 {
+		return fmt.Errorf("can't set %d LEDs", count)
+	}
+	if err := l.checkBounds(0, count); err != nil {
+		return err
+	}
+
+	colors := make([]Color, count)
+	for i := range colors {
+		colors[i] = c
+	}
+
+	return l.SetAllRGBValues(0, colors)
+}
+
+// SetStripLength sets the number of LEDs of the strip (up to MaxStripLength).
+// Setting colors beyond the strip length fails with an error.
+func (l *LedStrip) SetStripLength(n uint16) error {
+	if n > MaxStripLength {
+		return fmt.Errorf("strip length %d exceeds the maximum of %d LEDs", n, MaxStripLength)
+	}
+
+	l.length = int(n)
+	return nil
+}
+
+// checkBounds makes sure 'count' LEDs beginning from 'index' fit the strip.
+func (l *LedStrip) checkBounds(index uint16, count int) error {
+	if int(index)+count > l.length {
+		return fmt.Errorf("setting %d LEDs from index %d exceeds the strip length of %d LEDs", count, index, l.length)
 	}
 
 	return nil
@@ -75,12 +130,63 @@ func min(a, b int) int {
 
 // SetRGBValues sets up to 16 color values beginning from 'index' to the values in 'colors'.
 func (l *LedStrip) SetRGBValues(index uint16, colors []Color) error {
+	// Create packet
+	p, err := l.rgbValuesPacket(index, colors)
+	if err != nil {
+		return err
+	}
+
+	// Send packet
+	_, err = l.t.Send(p)
+	return err
+}
+
+// SetAllRGBValuesPipelined works like SetAllRGBValues but creates all chunks before sending
+// them, so nothing is sent if a chunk is invalid. The chunks expect no response, they are
+// sent in order one after another without waiting for the bricklet. It returns the first error encountered.
+func (l *LedStrip) SetAllRGBValuesPipelined(index uint16, colors []Color) error {
+	// Check the bounds before sending anything
+	if err := l.checkBounds(index, len(colors)); err != nil {
+		return err
+	}
+
+	// Create all packets up front
+	var packets []*tinkerforge.Packet
+	for len(colors) > 0 {
+		p, err := l.rgbValuesPacket(index, colors)
+		if err != nil {
+			return err
+		}
+		packets = append(packets, p)
+
+		// calculate the remaining slice
+		colors = colors[min(len(colors), MaxLEDsPerFrame):]
+		index += MaxLEDsPerFrame
+	}
+
+	// Send the packets in order, Send returns as soon as a packet is written
+	for _, p := range packets {
+		if _, err := l.t.Send(p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rgbValuesPacket creates the packet for setting up to 16 color values beginning from 'index'.
+func (l *LedStrip) rgbValuesPacket(index uint16, colors []Color) (*tinkerforge.Packet, error) {
 	// The rgb data
-	r, g, b := [16]byte{}, [16]byte{}, [16]byte{}
+	r, g, b := [MaxLEDsPerFrame]byte{}, [MaxLEDsPerFrame]byte{}, [MaxLEDsPerFrame]byte{}
 
 	// Trim the slice if necessary
-	if len(colors) > 16 {
-		colors = colors[:16]
+	if len(colors) > MaxLEDsPerFrame {
+		colors = colors[:MaxLEDsPerFrame]
+	}
+
+	// Make sure we don't write past the end of the strip
+	if err := l.checkBounds(index, len(colors)); err != nil {
+		return nil, err
 	}
 
 	// Copy the colors into the arrays, apply color mapping
@@ -90,22 +196,14 @@ func (l *LedStrip) SetRGBValues(index uint16, colors []Color) error {
 		b[i] = c[l.colorMap[2]]
 	}
 
-	// Create packet
-	p, err := tinkerforge.NewPacket(l.uid, 1, false, index, uint8(len(colors)), r, g, b)
-	if err != nil {
-		return err
-	}
-
-	// Send packet
-	_, err = l.t.Send(p)
-	return err
+	return tinkerforge.NewPacket(l.uid, 1, false, index, uint8(len(colors)), r, g, b)
 }
 
 // GetRGBValues retrieves the currently set RGB values of the LED strip beginning from 'index' and up to 'length' values.
 func (l *LedStrip) GetRGBValues(index uint16, length uint8) ([]Color, error) {
 	// Limit the length to 16 (maximum the protocol supports)
-	if length > 16 {
-		length = 16
+	if length > MaxLEDsPerFrame {
+		length = MaxLEDsPerFrame
 	}
 
 	// Create a new tinkerforge packet for function #2
@@ -121,7 +219,7 @@ func (l *LedStrip) GetRGBValues(index uint16, length uint8) ([]Color, error) {
 	}
 
 	// Decode the values from the answer
-	r, g, b := [16]byte{}, [16]byte{}, [16]byte{}
+	r, g, b := [MaxLEDsPerFrame]byte{}, [MaxLEDsPerFrame]byte{}, [MaxLEDsPerFrame]byte{}
 	if err = res.Decode(&r, &g, &b); err != nil {
 		return nil, err
 	}
@@ -298,9 +396,9 @@ func (f frameRenderedHandler) Handle(p *tinkerforge.Packet) {
 func (l *LedStrip) CallbackFrameRendered(handler func(uint16)) {
 
 	if handler == nil {
-		l.t.Handler(l.uid, 6, nil)
+		l.callbacks.Set(l.t, l.uid, 6, nil)
 	} else {
-		l.t.Handler(l.uid, 6, frameRenderedHandler(handler))
+		l.callbacks.Set(l.t, l.uid, 6, frameRenderedHandler(handler))
 	}
 
 }
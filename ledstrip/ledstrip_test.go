@@ -0,0 +1,84 @@
+package ledstrip
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+func TestSetAllRGBValuesPipelined(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	l, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// More chunks than sequence numbers, none of them is answered
+	colors := make([]Color, MaxStripLength)
+	if err := l.SetAllRGBValuesPipelined(0, colors); err != nil {
+		t.Fatal(err)
+	}
+
+	sent, err := m.WaitSent(MaxStripLength/MaxLEDsPerFrame, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sent) != MaxStripLength/MaxLEDsPerFrame {
+		t.Fatalf("sent %d packets, want %d", len(sent), MaxStripLength/MaxLEDsPerFrame)
+	}
+
+	for i, p := range sent {
+		index := binary.LittleEndian.Uint16(p.Payload())
+		if p.FunctionID() != 1 || p.ResponseExpected() || index != uint16(i*MaxLEDsPerFrame) {
+			t.Errorf("packet %d: got %v with index %d, want index %d", i, p, index, i*MaxLEDsPerFrame)
+		}
+	}
+}
+
+func TestSetAllRGBValuesPipelinedError(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	l, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Nothing is sent if the colors exceed the strip
+	if err := l.SetAllRGBValuesPipelined(MaxStripLength-10, make([]Color, 40)); err == nil {
+		t.Error("SetAllRGBValuesPipelined() succeeded beyond the strip length")
+	}
+	if len(m.Sent()) != 0 {
+		t.Errorf("sent %d packets, want 0", len(m.Sent()))
+	}
+}
+
+func TestFillColorCount(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	l, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, count := range []int{-1, MaxStripLength + 1, 1 << 30} {
+		if err := l.FillColor(count, Color{255, 0, 0}); err == nil {
+			t.Errorf("FillColor(%d) succeeded", count)
+		}
+	}
+	if len(m.Sent()) != 0 {
+		t.Errorf("invalid count was sent: %v", m.Sent())
+	}
+
+	if err := l.FillColor(20, Color{255, 0, 0}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.WaitSent(2, time.Second); err != nil {
+		t.Error(err)
+	}
+}
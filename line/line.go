@@ -0,0 +1,124 @@
+// Package line has control routines for the Line Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package line
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// Line is a control structure for Line Bricklets
+type Line struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+// New creates a new line control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*Line, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &Line{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// GetReflectivity returns the reflectivity of the surface (0 to 4095).
+// Larger values mean less reflectivity, e.g. a black line on white ground.
+func (l *Line) GetReflectivity() (uint16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(l.uid, 1, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := l.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the reflectivity
+	var reflectivity uint16
+	if err = res.Decode(&reflectivity); err != nil {
+		return 0, err
+	}
+
+	return reflectivity, nil
+}
+
+// SetReflectivityCallbackPeriod sets the period in ms with which the reflectivity callback is triggered.
+// The callback is only triggered if the reflectivity changed. A value of 0 turns the callback off.
+func (l *Line) SetReflectivityCallbackPeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(l.uid, 2, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = l.t.Send(p)
+	return err
+}
+
+// SetReflectivityCallbackThreshold sets the threshold for the reflectivity reached callback.
+// 'option' is one of the helpers.Threshold* constants.
+func (l *Line) SetReflectivityCallbackThreshold(option byte, min, max uint16) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(l.uid, 4, false, option, min, max)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = l.t.Send(p)
+	return err
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (l *Line) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(l.t, l.uid)
+	return i, err
+}
+
+type reflectivityHandler func(uint16)
+
+func (f reflectivityHandler) Handle(p *tinkerforge.Packet) {
+
+	var reflectivity uint16
+
+	if p.Decode(&reflectivity) != nil {
+		return
+	}
+	f(reflectivity)
+
+}
+
+// CallbackReflectivity is a convenience function for registering
+// a handler to be called periodically with the reflectivity.
+// The period is set with SetReflectivityCallbackPeriod.
+func (l *Line) CallbackReflectivity(handler func(uint16)) {
+
+	if handler == nil {
+		l.callbacks.Set(l.t, l.uid, 8, nil)
+	} else {
+		l.callbacks.Set(l.t, l.uid, 8, reflectivityHandler(handler))
+	}
+
+}
+
+// CallbackReflectivityReached is a convenience function for registering
+// a handler to be called when the threshold set by SetReflectivityCallbackThreshold is reached.
+func (l *Line) CallbackReflectivityReached(handler func(uint16)) {
+
+	if handler == nil {
+		l.callbacks.Set(l.t, l.uid, 9, nil)
+	} else {
+		l.callbacks.Set(l.t, l.uid, 9, reflectivityHandler(handler))
+	}
+
+}
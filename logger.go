@@ -0,0 +1,33 @@
+package tinkerforge
+
+// Logger receives the diagnostic output of the client.
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// nopLogger discards all output, it is the default logger
+type nopLogger struct{}
+
+func (nopLogger) Debugf(format string, args ...interface{}) {}
+func (nopLogger) Errorf(format string, args ...interface{}) {}
+
+// SetLogger sets the logger for diagnostic output, nil disables the output (default).
+func (t *tinkerforge) SetLogger(l Logger) {
+	if l == nil {
+		l = nopLogger{}
+	}
+
+	t.connMutex.Lock()
+	defer t.connMutex.Unlock()
+
+	t.logger = l
+}
+
+// log returns the current logger
+func (t *tinkerforge) log() Logger {
+	t.connMutex.RLock()
+	defer t.connMutex.RUnlock()
+
+	return t.logger
+}
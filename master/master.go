@@ -0,0 +1,156 @@
+// Package master has control routines for the Master Brick
+// Author: Tim Scheuermann (https://github.com/noxer)
+package master
+
+import (
+	"sync"
+
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// Master is a control structure for Master Bricks
+type Master struct {
+	t   tinkerforge.Tinkerforge
+	uid uint32
+
+	enumerate      tinkerforge.HandlerToken // handler registered with CallbackEnumerate
+	enumerateMutex sync.Mutex
+}
+
+// EnumerationType describes why an enumerate callback was sent.
+type EnumerationType = tinkerforge.EnumerationType
+
+const (
+	// EnumerationAvailable is sent as an answer to Enumerate
+	EnumerationAvailable = tinkerforge.EnumerationAvailable
+	// EnumerationConnected is sent when a device was (re)connected
+	EnumerationConnected = tinkerforge.EnumerationConnected
+	// EnumerationDisconnected is sent when a device was disconnected (USB only)
+	EnumerationDisconnected = tinkerforge.EnumerationDisconnected
+)
+
+// EnumerateResponse holds the information a device sends in the enumerate callback.
+type EnumerateResponse = tinkerforge.Enumeration
+
+// New creates a new Master Brick control for the brick with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*Master, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &Master{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// GetStackVoltage returns the voltage of the stack in mV.
+func (m *Master) GetStackVoltage() (uint16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(m.uid, 1, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := m.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the voltage
+	var voltage uint16
+	if err = res.Decode(&voltage); err != nil {
+		return 0, err
+	}
+
+	return voltage, nil
+}
+
+// GetStackCurrent returns the current flowing through the stack in mA.
+func (m *Master) GetStackCurrent() (uint16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(m.uid, 2, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := m.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the current
+	var current uint16
+	if err = res.Decode(&current); err != nil {
+		return 0, err
+	}
+
+	return current, nil
+}
+
+// GetChipTemperature returns the temperature of the microcontroller in °C/10.
+func (m *Master) GetChipTemperature() (int16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(m.uid, 242, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := m.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the temperature
+	var temperature int16
+	if err = res.Decode(&temperature); err != nil {
+		return 0, err
+	}
+
+	return temperature, nil
+}
+
+// Enumerate asks all connected devices to identify themselves.
+// The answers arrive through the handler registered with CallbackEnumerate.
+func (m *Master) Enumerate() error {
+	// Create a new tinkerforge packet, enumerate is broadcast to UID 0
+	p, err := tinkerforge.NewPacket(0, 254, false)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = m.t.Send(p)
+	return err
+}
+
+// GetIdentity returns the position information of the brick and its identifier.
+func (m *Master) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(m.t, m.uid)
+	return i, err
+}
+
+// CallbackEnumerate is a convenience function for registering
+// a handler to be called for every device answering an enumeration.
+// The handler is registered for all UIDs and replaces the previous one, passing nil removes it.
+func (m *Master) CallbackEnumerate(handler func(EnumerateResponse)) {
+
+	m.enumerateMutex.Lock()
+	defer m.enumerateMutex.Unlock()
+
+	// Only remove our own handler, others may be registered for UID 0 as well
+	if m.enumerate != (tinkerforge.HandlerToken{}) {
+		m.t.RemoveHandler(m.enumerate)
+		m.enumerate = tinkerforge.HandlerToken{}
+	}
+
+	if handler != nil {
+		m.enumerate = m.t.Handler(0, 253, tinkerforge.EnumerateHandler(handler))
+	}
+
+}
@@ -0,0 +1,165 @@
+// Package moisture has control routines for the Moisture Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package moisture
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// Moisture is a control structure for Moisture Bricklets
+type Moisture struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+// New creates a new moisture control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*Moisture, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &Moisture{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// GetMoistureValue returns the raw moisture value of the sensor (0 to 4095).
+// Larger values mean more moisture.
+func (m *Moisture) GetMoistureValue() (uint16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(m.uid, 1, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := m.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the moisture
+	var moisture uint16
+	if err = res.Decode(&moisture); err != nil {
+		return 0, err
+	}
+
+	return moisture, nil
+}
+
+// SetMoistureCallbackPeriod sets the period in ms with which the moisture callback is triggered.
+// The callback is only triggered if the moisture changed. A value of 0 turns the callback off.
+func (m *Moisture) SetMoistureCallbackPeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(m.uid, 2, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = m.t.Send(p)
+	return err
+}
+
+// SetMoistureCallbackThreshold sets the threshold for the moisture reached callback.
+// 'option' is one of the helpers.Threshold* constants.
+func (m *Moisture) SetMoistureCallbackThreshold(option byte, min, max uint16) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(m.uid, 4, false, option, min, max)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = m.t.Send(p)
+	return err
+}
+
+// SetMovingAverage sets the length (0 to 100) of the moving average for the moisture value.
+// A value of 0 turns averaging off.
+func (m *Moisture) SetMovingAverage(average uint8) error {
+	if average > 100 {
+		return tinkerforge.ErrInvalidParam
+	}
+
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(m.uid, 10, false, average)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = m.t.Send(p)
+	return err
+}
+
+// GetMovingAverage returns the length of the moving average as set by SetMovingAverage.
+func (m *Moisture) GetMovingAverage() (uint8, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(m.uid, 11, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := m.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the moving average
+	var average uint8
+	if err = res.Decode(&average); err != nil {
+		return 0, err
+	}
+
+	return average, nil
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (m *Moisture) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(m.t, m.uid)
+	return i, err
+}
+
+type moistureHandler func(uint16)
+
+func (f moistureHandler) Handle(p *tinkerforge.Packet) {
+
+	var moisture uint16
+
+	if p.Decode(&moisture) != nil {
+		return
+	}
+	f(moisture)
+
+}
+
+// CallbackMoisture is a convenience function for registering
+// a handler to be called periodically with the moisture.
+// The period is set with SetMoistureCallbackPeriod.
+func (m *Moisture) CallbackMoisture(handler func(uint16)) {
+
+	if handler == nil {
+		m.callbacks.Set(m.t, m.uid, 8, nil)
+	} else {
+		m.callbacks.Set(m.t, m.uid, 8, moistureHandler(handler))
+	}
+
+}
+
+// CallbackMoistureReached is a convenience function for registering
+// a handler to be called when the threshold set by SetMoistureCallbackThreshold is reached.
+func (m *Moisture) CallbackMoistureReached(handler func(uint16)) {
+
+	if handler == nil {
+		m.callbacks.Set(m.t, m.uid, 9, nil)
+	} else {
+		m.callbacks.Set(m.t, m.uid, 9, moistureHandler(handler))
+	}
+
+}
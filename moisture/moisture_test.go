@@ -0,0 +1,35 @@
+package moisture
+
+import (
+	"testing"
+
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+func TestSetMovingAverage(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	mo, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Values up to 100 are sent
+	m.Expect(t, mo.uid, []tinkerforgetest.Expectation{
+		{Name: "SetMovingAverage(0)", Call: func() error { return mo.SetMovingAverage(0) }, FuncID: 10, Payload: []byte{0}},
+		{Name: "SetMovingAverage(100)", Call: func() error { return mo.SetMovingAverage(100) }, FuncID: 10, Payload: []byte{100}},
+	})
+
+	// Longer averages are rejected before sending
+	before := len(m.Sent())
+	for _, average := range []uint8{101, 255} {
+		if err := mo.SetMovingAverage(average); err != tinkerforge.ErrInvalidParam {
+			t.Errorf("SetMovingAverage(%d) error = %v, want ErrInvalidParam", average, err)
+		}
+	}
+	if sent := len(m.Sent()) - before; sent != 0 {
+		t.Errorf("sent %d packets for invalid averages, want 0", sent)
+	}
+}
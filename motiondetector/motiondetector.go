@@ -0,0 +1,98 @@
+// Package motiondetector has control routines for the Motion Detector Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package motiondetector
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// MotionDetector is a control structure for Motion Detector Bricklets
+type MotionDetector struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+const (
+	// MotionNotDetected is returned by GetMotionDetected if there is no motion
+	MotionNotDetected uint8 = 0
+	// MotionDetected is returned by GetMotionDetected if motion was detected
+	MotionDetected uint8 = 1
+)
+
+// New creates a new motion detector control for the bricklet with 'uid'.
+// Like all constructors it takes the Base58 UID printed on the bricklet (as in
+// tinkerforge.Enumeration), use helpers.U32ToBase58 for a numeric UID.
+func New(t tinkerforge.Tinkerforge, uid string) (*MotionDetector, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &MotionDetector{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// GetMotionDetected returns MotionDetected if a motion was detected within the last detection cycle
+// (about 5 seconds), otherwise MotionNotDetected.
+func (m *MotionDetector) GetMotionDetected() (uint8, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(m.uid, 1, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := m.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the motion state
+	var motion uint8
+	if err = res.Decode(&motion); err != nil {
+		return 0, err
+	}
+
+	return motion, nil
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (m *MotionDetector) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(m.t, m.uid)
+	return i, err
+}
+
+type eventHandler func()
+
+func (f eventHandler) Handle(p *tinkerforge.Packet) {
+	f()
+}
+
+// CallbackMotionDetected is a convenience function for registering
+// a handler to be called when a motion is detected.
+func (m *MotionDetector) CallbackMotionDetected(handler func()) {
+
+	if handler == nil {
+		m.callbacks.Set(m.t, m.uid, 2, nil)
+	} else {
+		m.callbacks.Set(m.t, m.uid, 2, eventHandler(handler))
+	}
+
+}
+
+// CallbackDetectionCycleEnded is a convenience function for registering
+// a handler to be called when a detection cycle ended.
+// A new motion can only be detected after the cycle ended.
+func (m *MotionDetector) CallbackDetectionCycleEnded(handler func()) {
+
+	if handler == nil {
+		m.callbacks.Set(m.t, m.uid, 3, nil)
+	} else {
+		m.callbacks.Set(m.t, m.uid, 3, eventHandler(handler))
+	}
+
+}
@@ -0,0 +1,168 @@
+// Package multitouch has control routines for the Multi Touch Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package multitouch
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// MultiTouch is a control structure for Multi Touch Bricklets
+type MultiTouch struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+const (
+	// Electrodes is the number of electrodes of the bricklet
+	Electrodes = 12
+	// Proximity is the bit index of the proximity electrode
+	Proximity = 12
+)
+
+// Touched returns whether 'electrode' (0 to 11 or Proximity) is set in the touch state 'state'.
+func Touched(state uint16, electrode uint8) bool {
+	return electrode <= Proximity && state&(1<<electrode) != 0
+}
+
+// Electrode returns the bitmask for 'electrode' (0 to 11 or Proximity), e.g. to build an electrode config.
+func Electrode(electrode uint8) uint16 {
+	if electrode > Proximity {
+		return 0
+	}
+
+	return 1 << electrode
+}
+
+// New creates a new multi touch control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*MultiTouch, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &MultiTouch{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// GetTouchState returns the touch state as a bitmask. Bits 0 to 11 represent the electrodes,
+// bit 12 the proximity. Use Touched to test single electrodes.
+func (m *MultiTouch) GetTouchState() (uint16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(m.uid, 1, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := m.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the touch state
+	var state uint16
+	if err = res.Decode(&state); err != nil {
+		return 0, err
+	}
+
+	return state, nil
+}
+
+// Recalibrate recalibrates the electrodes. Call it after changing the electrode configuration.
+func (m *MultiTouch) Recalibrate() error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(m.uid, 2, false)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = m.t.Send(p)
+	return err
+}
+
+// SetElectrodeConfig enables the electrodes set in 'enabledElectrodes' (see Electrode).
+// Disabling electrodes reduces the reaction time of the others.
+func (m *MultiTouch) SetElectrodeConfig(enabledElectrodes uint16) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(m.uid, 3, false, enabledElectrodes)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = m.t.Send(p)
+	return err
+}
+
+// GetElectrodeConfig returns the electrode configuration as set by SetElectrodeConfig.
+func (m *MultiTouch) GetElectrodeConfig() (uint16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(m.uid, 4, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := m.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the electrode config
+	var enabledElectrodes uint16
+	if err = res.Decode(&enabledElectrodes); err != nil {
+		return 0, err
+	}
+
+	return enabledElectrodes, nil
+}
+
+// SetElectrodeSensitivity sets the sensitivity (5 to 201) of the electrodes.
+// Call Recalibrate after changing the sensitivity.
+func (m *MultiTouch) SetElectrodeSensitivity(sensitivity uint8) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(m.uid, 6, false, sensitivity)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = m.t.Send(p)
+	return err
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (m *MultiTouch) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(m.t, m.uid)
+	return i, err
+}
+
+type touchStateHandler func(uint16)
+
+func (f touchStateHandler) Handle(p *tinkerforge.Packet) {
+
+	var state uint16
+
+	if p.Decode(&state) != nil {
+		return
+	}
+	f(state)
+
+}
+
+// CallbackTouchState is a convenience function for registering
+// a handler to be called when the touch state changes.
+func (m *MultiTouch) CallbackTouchState(handler func(uint16)) {
+
+	if handler == nil {
+		m.callbacks.Set(m.t, m.uid, 5, nil)
+	} else {
+		m.callbacks.Set(m.t, m.uid, 5, touchStateHandler(handler))
+	}
+
+}
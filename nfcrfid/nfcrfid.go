@@ -0,0 +1,228 @@
+// Package nfcrfid has control routines for the NFC/RFID Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package nfcrfid
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// NFCRFID is a control structure for NFC/RFID Bricklets
+type NFCRFID struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+const (
+	// TagTypeMifareClassic selects Mifare Classic tags
+	TagTypeMifareClassic uint8 = 0
+	// TagTypeType1 selects NFC Forum Type 1 tags
+	TagTypeType1 uint8 = 1
+	// TagTypeType2 selects NFC Forum Type 2 tags
+	TagTypeType2 uint8 = 2
+)
+
+const (
+	// StateInitialization is the state after start up
+	StateInitialization uint8 = 0
+	// StateIdle is the state when the bricklet is ready for a new request
+	StateIdle uint8 = 128
+	// StateError is the state after an error during initialization
+	StateError uint8 = 192
+	// StateRequestTagID is the state while a tag ID is requested
+	StateRequestTagID uint8 = 2
+	// StateRequestTagIDReady is the state after a tag ID was found
+	StateRequestTagIDReady uint8 = 130
+	// StateRequestTagIDError is the state after no tag ID was found
+	StateRequestTagIDError uint8 = 194
+	// StateAuthenticatingMifareClassicPage is the state while a page is authenticated
+	StateAuthenticatingMifareClassicPage uint8 = 3
+	// StateAuthenticatingMifareClassicPageReady is the state after a page was authenticated
+	StateAuthenticatingMifareClassicPageReady uint8 = 131
+	// StateAuthenticatingMifareClassicPageError is the state after an authentication failed
+	StateAuthenticatingMifareClassicPageError uint8 = 195
+	// StateWritePage is the state while a page is written
+	StateWritePage uint8 = 4
+	// StateWritePageReady is the state after a page was written
+	StateWritePageReady uint8 = 132
+	// StateWritePageError is the state after writing a page failed
+	StateWritePageError uint8 = 196
+	// StateRequestPage is the state while a page is requested
+	StateRequestPage uint8 = 5
+	// StateRequestPageReady is the state after a page was read, see GetPage
+	StateRequestPageReady uint8 = 133
+	// StateRequestPageError is the state after reading a page failed
+	StateRequestPageError uint8 = 197
+)
+
+// New creates a new NFC/RFID control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*NFCRFID, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &NFCRFID{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// RequestTagID searches for a tag of 'tagType' (one of the TagType* constants).
+// Wait for StateRequestTagIDReady and read the result with GetTagID.
+func (n *NFCRFID) RequestTagID(tagType uint8) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(n.uid, 1, false, tagType)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = n.t.Send(p)
+	return err
+}
+
+// GetTagID returns the type, the length and the ID of the tag found by RequestTagID.
+// Only the first 'tidLength' bytes of 'tid' are valid.
+func (n *NFCRFID) GetTagID() (tagType uint8, tidLength uint8, tid [7]byte, err error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(n.uid, 2, true)
+	if err != nil {
+		return 0, 0, tid, err
+	}
+
+	// Send the packet
+	res, err := n.t.Send(p)
+	if err != nil {
+		return 0, 0, tid, err
+	}
+
+	// Decode the tag ID
+	if err = res.Decode(&tagType, &tidLength, &tid); err != nil {
+		return 0, 0, [7]byte{}, err
+	}
+
+	return tagType, tidLength, tid, nil
+}
+
+// GetState returns the current state (one of the State* constants) and whether the bricklet is idle.
+func (n *NFCRFID) GetState() (state uint8, idle bool, err error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(n.uid, 3, true)
+	if err != nil {
+		return 0, false, err
+	}
+
+	// Send the packet
+	res, err := n.t.Send(p)
+	if err != nil {
+		return 0, false, err
+	}
+
+	// Decode the state
+	if err = res.Decode(&state, &idle); err != nil {
+		return 0, false, err
+	}
+
+	return state, idle, nil
+}
+
+// AuthenticateMifareClassicPage authenticates a page of a Mifare Classic tag with key A (keyNumber 0)
+// or key B (keyNumber 1). Wait for StateAuthenticatingMifareClassicPageReady before reading or writing.
+func (n *NFCRFID) AuthenticateMifareClassicPage(page uint16, keyNumber uint8, key [6]byte) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(n.uid, 4, false, page, keyNumber, key)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = n.t.Send(p)
+	return err
+}
+
+// WritePage writes 16 bytes starting at 'page'. Depending on the tag type this covers one or more pages.
+// Wait for StateWritePageReady to confirm the write.
+func (n *NFCRFID) WritePage(page uint16, data [16]byte) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(n.uid, 5, false, page, data)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = n.t.Send(p)
+	return err
+}
+
+// RequestPage reads 16 bytes starting at 'page'.
+// Wait for StateRequestPageReady and read the result with GetPage.
+func (n *NFCRFID) RequestPage(page uint16) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(n.uid, 6, false, page)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = n.t.Send(p)
+	return err
+}
+
+// GetPage returns the 16 bytes read by RequestPage.
+func (n *NFCRFID) GetPage() ([16]byte, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(n.uid, 7, true)
+	if err != nil {
+		return [16]byte{}, err
+	}
+
+	// Send the packet
+	res, err := n.t.Send(p)
+	if err != nil {
+		return [16]byte{}, err
+	}
+
+	// Decode the page
+	var data [16]byte
+	if err = res.Decode(&data); err != nil {
+		return [16]byte{}, err
+	}
+
+	return data, nil
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (n *NFCRFID) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(n.t, n.uid)
+	return i, err
+}
+
+type stateChangedHandler func(uint8, bool)
+
+func (f stateChangedHandler) Handle(p *tinkerforge.Packet) {
+
+	var (
+		state uint8
+		idle  bool
+	)
+
+	if p.Decode(&state, &idle) != nil {
+		return
+	}
+	f(state, idle)
+
+}
+
+// CallbackStateChanged is a convenience function for registering
+// a handler to be called when the state of the bricklet changes.
+func (n *NFCRFID) CallbackStateChanged(handler func(state uint8, idle bool)) {
+
+	if handler == nil {
+		n.callbacks.Set(n.t, n.uid, 8, nil)
+	} else {
+		n.callbacks.Set(n.t, n.uid, 8, stateChangedHandler(handler))
+	}
+
+}
@@ -0,0 +1,72 @@
+package nfcrfid
+
+import (
+	"testing"
+
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+func TestWire(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	n, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := [6]byte{0xff, 0xfe, 0xfd, 0xfc, 0xfb, 0xfa}
+	var data [16]byte
+	for i := range data {
+		data[i] = byte(i + 1)
+	}
+
+	m.Expect(t, n.uid, []tinkerforgetest.Expectation{
+		{Name: "AuthenticateMifareClassicPage", Call: func() error { return n.AuthenticateMifareClassicPage(0x0105, 1, key) }, FuncID: 4, Payload: []byte{0x05, 0x01, 1, 0xff, 0xfe, 0xfd, 0xfc, 0xfb, 0xfa}},
+		{Name: "WritePage", Call: func() error { return n.WritePage(0x0105, data) }, FuncID: 5, Payload: append([]byte{0x05, 0x01}, data[:]...)},
+	})
+}
+
+func TestGetTagID(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	n, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A 4 byte ID, the rest of the array is padding
+	tid := [7]byte{0xde, 0xad, 0xbe, 0xef}
+	if err := m.Respond(n.uid, 2, TagTypeType1, uint8(4), tid); err != nil {
+		t.Fatal(err)
+	}
+
+	tagType, tidLength, got, err := n.GetTagID()
+	if err != nil || tagType != TagTypeType1 || tidLength != 4 || got != tid {
+		t.Errorf("GetTagID() = %d, %d, % x, %v, want %d, 4, % x", tagType, tidLength, got, err, TagTypeType1, tid)
+	}
+}
+
+func TestGetPage(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	n, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var data [16]byte
+	for i := range data {
+		data[i] = byte(0xf0 - i)
+	}
+	if err := m.Respond(n.uid, 7, data); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := n.GetPage()
+	if err != nil || got != data {
+		t.Errorf("GetPage() = % x, %v, want % x", got, err, data)
+	}
+}
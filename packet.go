@@ -3,8 +3,14 @@ package tinkerforge
 import (
 	"bytes"
 	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"reflect"
+	"strings"
+	"sync"
 )
 
 // ErrorCode represents the error value returned by the brick(let)s
@@ -24,8 +30,15 @@ var (
 	ErrInvalidParam = errors.New("Invalid Parameter")
 	// ErrFuncNotSupported represents ECFuncNotSupported in Go
 	ErrFuncNotSupported = errors.New("Function is not supported")
+	// ErrInvalidLength is returned for packets with a length shorter than the header
+	ErrInvalidLength = errors.New("Invalid packet length")
+	// ErrPayloadTooLarge is returned if the parameters don't fit into a single packet
+	ErrPayloadTooLarge = errors.New("Payload exceeds the maximum packet length")
 )
 
+// MaxPayloadLength is the maximum number of payload bytes in a packet (255 bytes minus the header)
+const MaxPayloadLength = 255 - 8
+
 // Packet holds all information about a sent or received packet
 type Packet struct {
 	uid       uint32
@@ -46,6 +59,11 @@ func NewPacket(uid uint32, funcID uint8, respExp bool, params ...interface{}) (*
 		return nil, err
 	}
 
+	// The length header can't describe a larger packet
+	if len(payload) > MaxPayloadLength {
+		return nil, ErrPayloadTooLarge
+	}
+
 	return &Packet{
 		uid:       uid,
 		funcID:    funcID,
@@ -58,36 +76,61 @@ func NewPacket(uid uint32, funcID uint8, respExp bool, params ...interface{}) (*
 
 }
 
-func readPacket(data []byte) (*Packet, error) {
+// ReadPacket reads exactly one packet from 'r'.
+func ReadPacket(r io.Reader) (*Packet, error) {
+	var buf [maxPacketLength]byte
+
+	data, err := readFrame(r, &buf)
+	if err != nil {
+		return nil, err
+	}
 
-	re := bytes.NewReader(data)
+	return readPacket(data)
+}
 
-	header := struct {
-		UID   uint32
-		Len   uint8
-		Func  uint8
-		Seq   uint8
-		Flags uint8
-	}{}
+// maxPacketLength is the maximum length of a packet including the header
+const maxPacketLength = 255
 
-	if err := binary.Read(re, binary.LittleEndian, &header); err != nil && err.Error() != "EOF" {
+// readFrame reads the bytes of one packet from 'r' into 'buf' and returns them
+func readFrame(r io.Reader, buf *[maxPacketLength]byte) ([]byte, error) {
+	// Read the header to get the length of the packet
+	if _, err := io.ReadFull(r, buf[:8]); err != nil {
 		return nil, err
 	}
 
-	respExp := header.Seq&0x08 != 0
-	seqNum := header.Seq >> 4
-	callback := seqNum == 0
-	errCode := header.Flags >> 6
+	// The length includes the 8 byte header, anything shorter means the stream is out of sync
+	length := buf[4]
+	if length < 8 {
+		return nil, ErrInvalidLength
+	}
 
-	payload := make([]byte, header.Len-8)
-	re.Read(payload)
-	if _, err := re.Read(payload); err != nil && err.Error() != "EOF" {
+	// Read the payload
+	if _, err := io.ReadFull(r, buf[8:length]); err != nil {
 		return nil, err
 	}
 
+	return buf[:length], nil
+}
+
+// readPacket decodes the packet in 'data', the payload is copied so data can be reused
+func readPacket(data []byte) (*Packet, error) {
+
+	// The length includes the 8 byte header
+	if len(data) < 8 || int(data[4]) < 8 || int(data[4]) > len(data) {
+		return nil, ErrInvalidLength
+	}
+
+	respExp := data[6]&0x08 != 0
+	seqNum := data[6] >> 4
+	callback := seqNum == 0
+	errCode := data[7] >> 6
+
+	payload := make([]byte, data[4]-8)
+	copy(payload, data[8:data[4]])
+
 	p := &Packet{
-		uid:       header.UID,
-		funcID:    header.Func,
+		uid:       binary.LittleEndian.Uint32(data[0:4]),
+		funcID:    data[5],
 		seqNum:    seqNum,
 		respExp:   respExp,
 		errorCode: ErrorCode(errCode),
@@ -99,18 +142,27 @@ func readPacket(data []byte) (*Packet, error) {
 	return p, nil
 }
 
-// Decode decodes the payload of a packet into a number of variables
+// Decode decodes the payload of a packet into a number of variables.
+// The values are read little endian, signed integers (int8 to int64) are
+// decoded as two's complement, so 0xFF 0xFF decodes to int16(-1).
+// If the payload ends before all variables are read, io.ErrUnexpectedEOF is returned.
 func (p *Packet) Decode(vars ...interface{}) error {
 
 	re := bytes.NewReader(p.payload)
 
-	for _, v := range vars {
+	for i, v := range vars {
 
 		if err := binary.Read(re, binary.LittleEndian, v); err != nil {
-			if err.Error() == "EOF" {
+			// An empty payload leaves all variables untouched
+			if err == io.EOF && i == 0 {
 				return nil
 			}
 
+			// The payload ended before all variables were read
+			if err == io.EOF {
+				return io.ErrUnexpectedEOF
+			}
+
 			return err
 		}
 
@@ -119,6 +171,26 @@ func (p *Packet) Decode(vars ...interface{}) error {
 	return nil
 }
 
+// DecodeString decodes the first 'length' bytes of the payload as a fixed size
+// character array, trailing NUL bytes and spaces are removed.
+func (p *Packet) DecodeString(length int) (string, error) {
+	data := make([]byte, length)
+	if err := p.Decode(data); err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(data), "\x00 "), nil
+}
+
+// WriteString copies 's' into the fixed size character array 'buf'.
+// Longer strings are truncated, shorter ones are padded with NUL bytes.
+func WriteString(buf []byte, s string) {
+	n := copy(buf, s)
+	for i := n; i < len(buf); i++ {
+		buf[i] = 0
+	}
+}
+
 // UID returns the UID of the packet source / destination
 func (p *Packet) UID() uint32 {
 	return p.uid
@@ -176,6 +248,44 @@ func (p *Packet) Payload() []byte {
 	return p.payload
 }
 
+// Clone returns a copy of the packet with its own payload
+func (p *Packet) Clone() *Packet {
+	c := *p
+	if p.payload != nil {
+		c.payload = make([]byte, len(p.payload))
+		copy(c.payload, p.payload)
+	}
+
+	return &c
+}
+
+// String returns a human readable representation of the packet for debugging
+func (p *Packet) String() string {
+	return fmt.Sprintf("Packet{UID: %d, FuncID: %d, SeqNum: %d, RespExp: %t, Callback: %t, ErrorCode: %d, Payload: [% x]}",
+		p.uid, p.funcID, p.seqNum, p.respExp, p.callback, p.errorCode, p.payload)
+}
+
+// MarshalJSON encodes the packet as a JSON object, the payload is hex encoded
+func (p *Packet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		UID              uint32    `json:"uid"`
+		FunctionID       uint8     `json:"functionID"`
+		SequenceNum      uint8     `json:"sequenceNum"`
+		ResponseExpected bool      `json:"responseExpected"`
+		Callback         bool      `json:"callback"`
+		ErrorCode        ErrorCode `json:"errorCode"`
+		Payload          string    `json:"payload"`
+	}{
+		UID:              p.uid,
+		FunctionID:       p.funcID,
+		SequenceNum:      p.seqNum,
+		ResponseExpected: p.respExp,
+		Callback:         p.callback,
+		ErrorCode:        p.errorCode,
+		Payload:          hex.EncodeToString(p.payload),
+	})
+}
+
 // Serialize converts the packet into a byte slice for sending
 func (p *Packet) Serialize(wr io.Writer, seqNum byte) error {
 	// Send header and payload
@@ -186,73 +296,67 @@ func (p *Packet) Serialize(wr io.Writer, seqNum byte) error {
 	return p.writePayload(wr)
 }
 
+// paramBuffers holds reusable buffers for encoding packet parameters
+var paramBuffers = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
 func parseParams(params []interface{}) ([]byte, error) {
 
-	wr := bytes.NewBuffer(make([]byte, 0))
+	wr := paramBuffers.Get().(*bytes.Buffer)
+	wr.Reset()
+	defer paramBuffers.Put(wr)
 
 	for _, p := range params {
 
+		// Only fixed size values can be encoded in the protocol
+		switch reflect.Indirect(reflect.ValueOf(p)).Kind() {
+		case reflect.Slice, reflect.Map, reflect.String, reflect.Interface, reflect.Invalid:
+			return nil, fmt.Errorf("parameter of type %T has no fixed size, use an array instead", p)
+		}
+
 		if err := binary.Write(wr, binary.LittleEndian, p); err != nil {
 			return nil, err
 		}
 
 	}
 
-	return wr.Bytes(), nil
+	// The buffer is reused, so the packet gets its own copy
+	payload := make([]byte, wr.Len())
+	copy(payload, wr.Bytes())
+	return payload, nil
 
 }
 
 func (p *Packet) writeHeader(wr io.Writer, seqNum byte) error {
 
-	// Header structure
-	header := &struct {
-		UID    uint32
-		Len    uint8
-		FuncID uint8
-		Seq    uint8
-		Flags  uint8
-	}{}
+	// Header structure: UID, length, function ID, sequence number and flags
+	var header [8]byte
 
 	// Fill header
-	header.UID = p.uid
-	header.Len = p.Length()
-	header.FuncID = p.funcID
+	binary.LittleEndian.PutUint32(header[0:4], p.uid)
+	header[4] = p.Length()
+	header[5] = p.funcID
 	seqNum = seqNum << 4
 	if p.respExp {
 		seqNum |= 0x08
 	}
-	header.Seq = uint8(seqNum)
-	header.Flags = uint8(p.errorCode << 6)
+	header[6] = seqNum
+	header[7] = uint8(p.errorCode << 6)
 
 	// Send header
-	return binary.Write(wr, binary.LittleEndian, header)
-}
-
-func (p *Packet) writePayload(wr io.Writer) error {
-	_, err := wr.Write(p.payload)
+	_, err := wr.Write(header[:])
 	return err
 }
 
-// Scans a byte stream for packets and returns them as byte arrays
-func scanPacket(data []byte, atEOF bool) (advance int, token []byte, err error) {
-	// We are unable to read the length of the packet.
-	if len(data) < 5 {
-		if atEOF {
-			return 0, nil, errors.New("EOF")
-		}
-
-		return 0, nil, nil
-	}
-
-	// Check the length of the data and the packet
-	if len(data) >= int(data[4]) {
-		return int(data[4]), data[:data[4]], nil
-	}
-
-	// The packet is incomplete but we are at EOF
-	if atEOF {
-		return 0, nil, errors.New("EOF")
+func (p *Packet) writePayload(wr io.Writer) error {
+	// Synchronous transports (e.g. net.Pipe) block on empty writes
+	if len(p.payload) == 0 {
+		return nil
 	}
 
-	return 0, nil, nil
+	_, err := wr.Write(p.payload)
+	return err
 }
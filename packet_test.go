@@ -0,0 +1,200 @@
+package tinkerforge
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"testing"
+)
+
+func TestReadPacketPrintsNothing(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stdout := os.Stdout
+	os.Stdout = w
+	_, err = ReadPacket(bytes.NewReader([]byte{1, 0, 0, 0, 10, 2, 0x10, 0, 0xAA, 0xBB}))
+	os.Stdout = stdout
+	w.Close()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, _ := ioutil.ReadAll(r)
+	if len(out) != 0 {
+		t.Errorf("ReadPacket() printed %q", out)
+	}
+}
+
+func TestReadPacketPayload(t *testing.T) {
+	// UID 0x04030201, length 12, function 7, sequence number 3 with response expected
+	data := []byte{0x01, 0x02, 0x03, 0x04, 12, 7, 0x38, 0, 0x34, 0x12, 0x78, 0x56}
+
+	p, err := ReadPacket(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p.UID() != 0x04030201 || p.FunctionID() != 7 || p.SequenceNum() != 3 || !p.ResponseExpected() || p.Callback() {
+		t.Errorf("ReadPacket() = %v, header mismatch", p)
+	}
+
+	var a, b uint16
+	if err := p.Decode(&a, &b); err != nil || a != 0x1234 || b != 0x5678 {
+		t.Errorf("Decode() = %#x, %#x, %v, want 0x1234, 0x5678", a, b, err)
+	}
+}
+
+func TestReadPacketShortPayload(t *testing.T) {
+	// The header announces 4 bytes of payload but only 2 arrive
+	data := []byte{0x01, 0x02, 0x03, 0x04, 12, 7, 0x38, 0, 0x34, 0x12}
+
+	if _, err := ReadPacket(bytes.NewReader(data)); err != io.ErrUnexpectedEOF {
+		t.Errorf("ReadPacket() error = %v, want io.ErrUnexpectedEOF", err)
+	}
+}
+
+func TestDecodeSigned(t *testing.T) {
+	p := &Packet{payload: []byte{0xFF, 0xFF, 0xFE, 0xFF, 0xFF, 0xFF, 0x80}}
+
+	var (
+		a int16
+		b int32
+		c int8
+	)
+	if err := p.Decode(&a, &b, &c); err != nil {
+		t.Fatal(err)
+	}
+
+	if a != -1 || b != -2 || c != -128 {
+		t.Errorf("Decode() = %d, %d, %d, want -1, -2, -128", a, b, c)
+	}
+}
+
+func TestDecodeLength(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload []byte
+		err     error
+	}{
+		{"exact fit", []byte{1, 0, 2, 0, 0, 0}, nil},
+		{"longer payload", []byte{1, 0, 2, 0, 0, 0, 9}, nil},
+		{"empty payload", []byte{}, nil},
+		{"short second variable", []byte{1, 0, 2, 0}, io.ErrUnexpectedEOF},
+		{"missing second variable", []byte{1, 0}, io.ErrUnexpectedEOF},
+		{"short first variable", []byte{1}, io.ErrUnexpectedEOF},
+	}
+
+	for _, test := range tests {
+		p := &Packet{payload: test.payload}
+
+		var (
+			a uint16
+			b uint32
+		)
+		if err := p.Decode(&a, &b); err != test.err {
+			t.Errorf("%s: Decode() error = %v, want %v", test.name, err, test.err)
+		}
+	}
+}
+
+func TestNewPacketPayloadLength(t *testing.T) {
+	tests := []struct {
+		name   string
+		params []interface{}
+		err    error
+	}{
+		{"maximum", []interface{}{[MaxPayloadLength]byte{}}, nil},
+		{"one byte too long", []interface{}{[MaxPayloadLength + 1]byte{}}, ErrPayloadTooLarge},
+		{"too long in total", []interface{}{[200]byte{}, [40]uint16{}}, ErrPayloadTooLarge},
+	}
+
+	for _, test := range tests {
+		p, err := NewPacket(1, 2, false, test.params...)
+		if err != test.err {
+			t.Errorf("%s: NewPacket() error = %v, want %v", test.name, err, test.err)
+		}
+		if err == nil && p.Length() != 255 {
+			t.Errorf("%s: Length() = %d, want 255", test.name, p.Length())
+		}
+	}
+
+	// Slices are rejected before their length matters
+	if _, err := NewPacket(1, 2, false, make([]byte, 300)); err == nil {
+		t.Error("NewPacket() with a []byte parameter succeeded")
+	}
+}
+
+func TestReadPacketInvalidLength(t *testing.T) {
+	for length := byte(0); length < 8; length++ {
+		data := []byte{1, 0, 0, 0, length, 2, 0x10, 0, 0, 0, 0, 0}
+
+		if _, err := ReadPacket(bytes.NewReader(data)); err != ErrInvalidLength {
+			t.Errorf("length %d: ReadPacket() error = %v, want ErrInvalidLength", length, err)
+		}
+		if _, err := readPacket(data); err != ErrInvalidLength {
+			t.Errorf("length %d: readPacket() error = %v, want ErrInvalidLength", length, err)
+		}
+	}
+}
+
+func TestReadPacketRandomStream(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 1000; i++ {
+		data := make([]byte, rnd.Intn(600))
+		rnd.Read(data)
+
+		// Reading garbage must end with an error, never with a panic or a bogus packet
+		re := bytes.NewReader(data)
+		for {
+			p, err := ReadPacket(re)
+			if err != nil {
+				if err != ErrInvalidLength && err != io.EOF && err != io.ErrUnexpectedEOF {
+					t.Fatalf("ReadPacket() unexpected error = %v", err)
+				}
+				break
+			}
+
+			if int(p.Length()) != 8+len(p.Payload()) || p.Length() < 8 {
+				t.Fatalf("ReadPacket() = %v with invalid length", p)
+			}
+		}
+	}
+}
+
+// ledPayload returns the parameters of a full LED strip frame (16 LEDs)
+func ledPayload() []interface{} {
+	var r, g, b [16]byte
+	return []interface{}{uint16(0), uint8(16), r, g, b}
+}
+
+func BenchmarkNewPacket(b *testing.B) {
+	params := ledPayload()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := NewPacket(1, 1, false, params...); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSerialize(b *testing.B) {
+	p, err := NewPacket(1, 1, false, ledPayload()...)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := p.Serialize(ioutil.Discard, 1); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
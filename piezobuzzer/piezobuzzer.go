@@ -0,0 +1,98 @@
+// Package piezobuzzer has control routines for the Piezo Buzzer Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package piezobuzzer
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// PiezoBuzzer is a control structure for Piezo Buzzer Bricklets
+type PiezoBuzzer struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+// MorseLength is the maximum length of a morse code
+const MorseLength = 60
+
+// New creates a new piezo buzzer control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*PiezoBuzzer, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &PiezoBuzzer{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// Beep beeps for 'duration' ms.
+func (b *PiezoBuzzer) Beep(duration uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(b.uid, 1, false, duration)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = b.t.Send(p)
+	return err
+}
+
+// MorseCode beeps the morse code 'code' made of '.' (dot), '-' (dash) and ' ' (pause).
+// Codes longer than 60 characters are truncated.
+func (b *PiezoBuzzer) MorseCode(code string) error {
+	// Pack the code into a fixed size, zero padded array
+	var morse [MorseLength]byte
+	tinkerforge.WriteString(morse[:], code)
+
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(b.uid, 2, false, morse)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = b.t.Send(p)
+	return err
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (b *PiezoBuzzer) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(b.t, b.uid)
+	return i, err
+}
+
+type finishedHandler func()
+
+func (f finishedHandler) Handle(p *tinkerforge.Packet) {
+	f()
+}
+
+// CallbackBeepFinished is a convenience function for registering
+// a handler to be called when a beep finished.
+func (b *PiezoBuzzer) CallbackBeepFinished(handler func()) {
+
+	if handler == nil {
+		b.callbacks.Set(b.t, b.uid, 3, nil)
+	} else {
+		b.callbacks.Set(b.t, b.uid, 3, finishedHandler(handler))
+	}
+
+}
+
+// CallbackMorseCodeFinished is a convenience function for registering
+// a handler to be called when a morse code finished.
+func (b *PiezoBuzzer) CallbackMorseCodeFinished(handler func()) {
+
+	if handler == nil {
+		b.callbacks.Set(b.t, b.uid, 4, nil)
+	} else {
+		b.callbacks.Set(b.t, b.uid, 4, finishedHandler(handler))
+	}
+
+}
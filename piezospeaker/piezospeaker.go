@@ -0,0 +1,156 @@
+// Package piezospeaker has control routines for the Piezo Speaker Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package piezospeaker
+
+import (
+	"context"
+	"time"
+
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// PiezoSpeaker is a control structure for Piezo Speaker Bricklets
+type PiezoSpeaker struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+const (
+	// MorseLength is the maximum length of a morse code
+	MorseLength = 60
+
+	// MinFrequency is the lowest frequency the speaker supports in Hz
+	MinFrequency = 585
+	// MaxFrequency is the highest frequency the speaker supports in Hz
+	MaxFrequency = 7100
+)
+
+// New creates a new piezo speaker control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*PiezoSpeaker, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &PiezoSpeaker{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// checkFrequency validates the frequency before it is sent to the bricklet.
+func checkFrequency(frequency uint16) error {
+	if frequency < MinFrequency || frequency > MaxFrequency {
+		return tinkerforge.ErrInvalidParam
+	}
+
+	return nil
+}
+
+// Beep beeps for 'duration' ms with 'frequency' (585 to 7100 Hz).
+func (s *PiezoSpeaker) Beep(duration uint32, frequency uint16) error {
+	if err := checkFrequency(frequency); err != nil {
+		return err
+	}
+
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(s.uid, 1, false, duration, frequency)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = s.t.Send(p)
+	return err
+}
+
+// MorseCode beeps the morse code 'code' made of '.' (dot), '-' (dash) and ' ' (pause)
+// with 'frequency' (585 to 7100 Hz). Codes longer than 60 characters are truncated.
+func (s *PiezoSpeaker) MorseCode(code string, frequency uint16) error {
+	if err := checkFrequency(frequency); err != nil {
+		return err
+	}
+
+	// Pack the code into a fixed size, zero padded array
+	var morse [MorseLength]byte
+	tinkerforge.WriteString(morse[:], code)
+
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(s.uid, 2, false, morse, frequency)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = s.t.Send(p)
+	return err
+}
+
+// CalibrationTimeout is how long Calibrate waits for the calibration to finish
+const CalibrationTimeout = 3 * time.Minute
+
+// Calibrate calibrates the resonance frequency of the speaker and returns whether it succeeded.
+// The calibration takes about two minutes, Calibrate waits up to CalibrationTimeout
+// regardless of the client's timeout.
+func (s *PiezoSpeaker) Calibrate() (bool, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(s.uid, 3, true)
+	if err != nil {
+		return false, err
+	}
+
+	// Send the packet, the deadline replaces the client's timeout
+	ctx, cancel := context.WithTimeout(context.Background(), CalibrationTimeout)
+	defer cancel()
+
+	res, err := s.t.SendContext(ctx, p)
+	if err != nil {
+		return false, err
+	}
+
+	// Decode the result
+	var ok bool
+	if err = res.Decode(&ok); err != nil {
+		return false, err
+	}
+
+	return ok, nil
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (s *PiezoSpeaker) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(s.t, s.uid)
+	return i, err
+}
+
+type finishedHandler func()
+
+func (f finishedHandler) Handle(p *tinkerforge.Packet) {
+	f()
+}
+
+// CallbackBeepFinished is a convenience function for registering
+// a handler to be called when a beep finished.
+func (s *PiezoSpeaker) CallbackBeepFinished(handler func()) {
+
+	if handler == nil {
+		s.callbacks.Set(s.t, s.uid, 4, nil)
+	} else {
+		s.callbacks.Set(s.t, s.uid, 4, finishedHandler(handler))
+	}
+
+}
+
+// CallbackMorseCodeFinished is a convenience function for registering
+// a handler to be called when a morse code finished.
+func (s *PiezoSpeaker) CallbackMorseCodeFinished(handler func()) {
+
+	if handler == nil {
+		s.callbacks.Set(s.t, s.uid, 5, nil)
+	} else {
+		s.callbacks.Set(s.t, s.uid, 5, finishedHandler(handler))
+	}
+
+}
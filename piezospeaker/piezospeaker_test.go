@@ -0,0 +1,80 @@
+package piezospeaker
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+func TestCalibrateIgnoresClientTimeout(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	// Any response would arrive too late for the client's timeout
+	m.SetTimeout(time.Nanosecond)
+
+	s, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Respond(s.uid, 3, true); err != nil {
+		t.Fatal(err)
+	}
+
+	ok, err := s.Calibrate()
+	if err != nil || !ok {
+		t.Errorf("Calibrate() = %t, %v, want true", ok, err)
+	}
+}
+
+func TestWire(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	s, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The morse code is packed into a zero padded 60 byte array followed by the frequency
+	short := make([]byte, MorseLength+2)
+	copy(short, "...---...")
+	short[MorseLength], short[MorseLength+1] = 0xe8, 0x03
+
+	long := make([]byte, MorseLength+2)
+	copy(long, strings.Repeat(".-", MorseLength/2))
+	long[MorseLength], long[MorseLength+1] = 0xbc, 0x1b
+
+	m.Expect(t, s.uid, []tinkerforgetest.Expectation{
+		{Name: "Beep", Call: func() error { return s.Beep(500, MinFrequency) }, FuncID: 1, Payload: []byte{0xf4, 0x01, 0, 0, 0x49, 0x02}},
+		{Name: "MorseCode", Call: func() error { return s.MorseCode("...---...", 1000) }, FuncID: 2, Payload: short},
+		{Name: "MorseCode truncated", Call: func() error { return s.MorseCode(strings.Repeat(".-", MorseLength), MaxFrequency) }, FuncID: 2, Payload: long},
+	})
+}
+
+func TestInvalidFrequency(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	s, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, frequency := range []uint16{0, MinFrequency - 1, MaxFrequency + 1, 65535} {
+		if err := s.Beep(100, frequency); err != tinkerforge.ErrInvalidParam {
+			t.Errorf("Beep(100, %d) = %v, want ErrInvalidParam", frequency, err)
+		}
+		if err := s.MorseCode("...", frequency); err != tinkerforge.ErrInvalidParam {
+			t.Errorf("MorseCode(\"...\", %d) = %v, want ErrInvalidParam", frequency, err)
+		}
+	}
+
+	if len(m.Sent()) != 0 {
+		t.Errorf("invalid frequency was sent: %v", m.Sent())
+	}
+}
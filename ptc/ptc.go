@@ -0,0 +1,184 @@
+// Package ptc has control routines for the PTC Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package ptc
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// PTC is a control structure for PTC Bricklets
+type PTC struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+const (
+	// Filter50Hz rejects noise with a frequency of 50Hz
+	Filter50Hz uint8 = 0
+	// Filter60Hz rejects noise with a frequency of 60Hz
+	Filter60Hz uint8 = 1
+
+	// WireMode2 is used for 2-wire sensors
+	WireMode2 uint8 = 2
+	// WireMode3 is used for 3-wire sensors
+	WireMode3 uint8 = 3
+	// WireMode4 is used for 4-wire sensors
+	WireMode4 uint8 = 4
+)
+
+// New creates a new PTC control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*PTC, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &PTC{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// GetTemperature returns the temperature of the sensor in °C/100.
+func (c *PTC) GetTemperature() (int32, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(c.uid, 1, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := c.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the temperature
+	var temperature int32
+	if err = res.Decode(&temperature); err != nil {
+		return 0, err
+	}
+
+	return temperature, nil
+}
+
+// GetResistance returns the raw resistance value of the sensor.
+// For Pt100 the resistance in Ohm is resistance * 390 / 32768.
+func (c *PTC) GetResistance() (int32, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(c.uid, 2, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := c.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the resistance
+	var resistance int32
+	if err = res.Decode(&resistance); err != nil {
+		return 0, err
+	}
+
+	return resistance, nil
+}
+
+// SetTemperatureCallbackPeriod sets the period in ms with which the temperature callback is triggered.
+// The callback is only triggered if the temperature changed. A value of 0 turns the callback off.
+func (c *PTC) SetTemperatureCallbackPeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(c.uid, 3, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = c.t.Send(p)
+	return err
+}
+
+// SetNoiseRejectionFilter sets the noise rejection filter to Filter50Hz or Filter60Hz.
+func (c *PTC) SetNoiseRejectionFilter(filter uint8) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(c.uid, 17, false, filter)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = c.t.Send(p)
+	return err
+}
+
+// IsSensorConnected returns whether a sensor is connected correctly.
+func (c *PTC) IsSensorConnected() (bool, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(c.uid, 19, true)
+	if err != nil {
+		return false, err
+	}
+
+	// Send the packet
+	res, err := c.t.Send(p)
+	if err != nil {
+		return false, err
+	}
+
+	// Decode the connection state
+	var connected bool
+	if err = res.Decode(&connected); err != nil {
+		return false, err
+	}
+
+	return connected, nil
+}
+
+// SetWireMode sets the wire mode of the sensor (WireMode2, WireMode3 or WireMode4).
+func (c *PTC) SetWireMode(mode uint8) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(c.uid, 20, false, mode)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = c.t.Send(p)
+	return err
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (c *PTC) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(c.t, c.uid)
+	return i, err
+}
+
+type temperatureHandler func(int32)
+
+func (f temperatureHandler) Handle(p *tinkerforge.Packet) {
+
+	var temperature int32
+
+	if p.Decode(&temperature) != nil {
+		return
+	}
+	f(temperature)
+
+}
+
+// CallbackTemperature is a convenience function for registering
+// a handler to be called periodically with the temperature.
+// The period is set with SetTemperatureCallbackPeriod.
+func (c *PTC) CallbackTemperature(handler func(int32)) {
+
+	if handler == nil {
+		c.callbacks.Set(c.t, c.uid, 13, nil)
+	} else {
+		c.callbacks.Set(c.t, c.uid, 13, temperatureHandler(handler))
+	}
+
+}
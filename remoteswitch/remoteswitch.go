@@ -0,0 +1,155 @@
+// Package remoteswitch has control routines for the Remote Switch Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package remoteswitch
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// RemoteSwitch is a control structure for Remote Switch Bricklets
+type RemoteSwitch struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+const (
+	// SwitchOff switches a socket off
+	SwitchOff uint8 = 0
+	// SwitchOn switches a socket on
+	SwitchOn uint8 = 1
+
+	// StateReady means the bricklet can switch a socket
+	StateReady uint8 = 0
+	// StateBusy means the bricklet is still sending a switching command
+	StateBusy uint8 = 1
+)
+
+// New creates a new remote switch control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*RemoteSwitch, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &RemoteSwitch{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// SwitchSocketA switches a type A socket with 'houseCode' and 'receiverCode' (0 to 31) to 'switchTo'
+// (SwitchOff or SwitchOn).
+func (rs *RemoteSwitch) SwitchSocketA(houseCode, receiverCode, switchTo uint8) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(rs.uid, 6, false, houseCode, receiverCode, switchTo)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = rs.t.Send(p)
+	return err
+}
+
+// SwitchSocketB switches a type B socket with 'address' (0 to 67108863) and 'unit' (0 to 15, 255 for all)
+// to 'switchTo' (SwitchOff or SwitchOn).
+func (rs *RemoteSwitch) SwitchSocketB(address uint32, unit, switchTo uint8) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(rs.uid, 7, false, address, unit, switchTo)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = rs.t.Send(p)
+	return err
+}
+
+// DimSocketB dims a type B socket with 'address' and 'unit' (0 to 15) to 'dimValue' (0 to 15).
+func (rs *RemoteSwitch) DimSocketB(address uint32, unit, dimValue uint8) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(rs.uid, 8, false, address, unit, dimValue)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = rs.t.Send(p)
+	return err
+}
+
+// SwitchSocketC switches a type C socket with 'systemCode' ('A' to 'P') and 'deviceCode' (1 to 16)
+// to 'switchTo' (SwitchOff or SwitchOn).
+func (rs *RemoteSwitch) SwitchSocketC(systemCode byte, deviceCode, switchTo uint8) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(rs.uid, 9, false, systemCode, deviceCode, switchTo)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = rs.t.Send(p)
+	return err
+}
+
+// GetSwitchingState returns StateBusy while a switching command is sent, otherwise StateReady.
+func (rs *RemoteSwitch) GetSwitchingState() (uint8, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(rs.uid, 2, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := rs.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the switching state
+	var state uint8
+	if err = res.Decode(&state); err != nil {
+		return 0, err
+	}
+
+	return state, nil
+}
+
+// SetRepeats sets how often a switching command is repeated (default 5).
+func (rs *RemoteSwitch) SetRepeats(repeats uint8) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(rs.uid, 4, false, repeats)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = rs.t.Send(p)
+	return err
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (rs *RemoteSwitch) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(rs.t, rs.uid)
+	return i, err
+}
+
+type switchingDoneHandler func()
+
+func (f switchingDoneHandler) Handle(p *tinkerforge.Packet) {
+	f()
+}
+
+// CallbackSwitchingDone is a convenience function for registering
+// a handler to be called when a switching command was sent.
+func (rs *RemoteSwitch) CallbackSwitchingDone(handler func()) {
+
+	if handler == nil {
+		rs.callbacks.Set(rs.t, rs.uid, 3, nil)
+	} else {
+		rs.callbacks.Set(rs.t, rs.uid, 3, switchingDoneHandler(handler))
+	}
+
+}
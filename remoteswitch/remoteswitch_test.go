@@ -0,0 +1,68 @@
+package remoteswitch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+func TestWire(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	rs, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.Expect(t, rs.uid, []tinkerforgetest.Expectation{
+		{Name: "SwitchSocketA", Call: func() error { return rs.SwitchSocketA(17, 31, SwitchOn) }, FuncID: 6, Payload: []byte{17, 31, 1}},
+		{Name: "SwitchSocketB", Call: func() error { return rs.SwitchSocketB(0x03fffffe, 15, SwitchOff) }, FuncID: 7, Payload: []byte{0xfe, 0xff, 0xff, 0x03, 15, 0}},
+		{Name: "DimSocketB", Call: func() error { return rs.DimSocketB(0x00123456, 3, 9) }, FuncID: 8, Payload: []byte{0x56, 0x34, 0x12, 0, 3, 9}},
+		{Name: "SwitchSocketC", Call: func() error { return rs.SwitchSocketC('P', 16, SwitchOn) }, FuncID: 9, Payload: []byte{'P', 16, 1}},
+		{Name: "SetRepeats", Call: func() error { return rs.SetRepeats(10) }, FuncID: 4, Payload: []byte{10}},
+	})
+}
+
+func TestGetSwitchingState(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	rs, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Respond(rs.uid, 2, StateBusy); err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := rs.GetSwitchingState()
+	if err != nil || state != StateBusy {
+		t.Errorf("GetSwitchingState() = %d, %v, want StateBusy", state, err)
+	}
+}
+
+func TestCallbackSwitchingDone(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	rs, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{}, 1)
+	rs.CallbackSwitchingDone(func() { done <- struct{}{} })
+
+	if err := m.Fire(rs.uid, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("switching done callback was not called")
+	}
+}
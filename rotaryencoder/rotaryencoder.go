@@ -0,0 +1,177 @@
+// Package rotaryencoder has control routines for the Rotary Encoder Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package rotaryencoder
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// RotaryEncoder is a control structure for Rotary Encoder Bricklets
+type RotaryEncoder struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+// New creates a new rotary encoder control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*RotaryEncoder, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &RotaryEncoder{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// GetCount returns the current count of the encoder.
+// If 'reset' is true the count is set to 0 after reading it.
+func (e *RotaryEncoder) GetCount(reset bool) (int32, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(e.uid, 1, true, reset)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := e.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the count
+	var count int32
+	if err = res.Decode(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// SetCountCallbackPeriod sets the period in ms with which the count callback is triggered.
+// The callback is only triggered if the count changed. A value of 0 turns the callback off.
+func (e *RotaryEncoder) SetCountCallbackPeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(e.uid, 2, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = e.t.Send(p)
+	return err
+}
+
+// SetCountCallbackThreshold sets the threshold for the count reached callback.
+// 'option' is one of the helpers.Threshold* constants.
+func (e *RotaryEncoder) SetCountCallbackThreshold(option byte, min, max int32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(e.uid, 4, false, option, min, max)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = e.t.Send(p)
+	return err
+}
+
+// IsPressed returns whether the button of the encoder is pressed.
+func (e *RotaryEncoder) IsPressed() (bool, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(e.uid, 10, true)
+	if err != nil {
+		return false, err
+	}
+
+	// Send the packet
+	res, err := e.t.Send(p)
+	if err != nil {
+		return false, err
+	}
+
+	// Decode the button state
+	var pressed bool
+	if err = res.Decode(&pressed); err != nil {
+		return false, err
+	}
+
+	return pressed, nil
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (e *RotaryEncoder) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(e.t, e.uid)
+	return i, err
+}
+
+type countHandler func(int32)
+
+func (f countHandler) Handle(p *tinkerforge.Packet) {
+
+	var count int32
+
+	if p.Decode(&count) != nil {
+		return
+	}
+	f(count)
+
+}
+
+// CallbackCount is a convenience function for registering
+// a handler to be called periodically with the count.
+// The period is set with SetCountCallbackPeriod.
+func (e *RotaryEncoder) CallbackCount(handler func(int32)) {
+
+	if handler == nil {
+		e.callbacks.Set(e.t, e.uid, 8, nil)
+	} else {
+		e.callbacks.Set(e.t, e.uid, 8, countHandler(handler))
+	}
+
+}
+
+// CallbackCountReached is a convenience function for registering
+// a handler to be called when the threshold set by SetCountCallbackThreshold is reached.
+func (e *RotaryEncoder) CallbackCountReached(handler func(int32)) {
+
+	if handler == nil {
+		e.callbacks.Set(e.t, e.uid, 9, nil)
+	} else {
+		e.callbacks.Set(e.t, e.uid, 9, countHandler(handler))
+	}
+
+}
+
+type buttonHandler func()
+
+func (f buttonHandler) Handle(p *tinkerforge.Packet) {
+	f()
+}
+
+// CallbackPressed is a convenience function for registering
+// a handler to be called when the button is pressed.
+func (e *RotaryEncoder) CallbackPressed(handler func()) {
+
+	if handler == nil {
+		e.callbacks.Set(e.t, e.uid, 11, nil)
+	} else {
+		e.callbacks.Set(e.t, e.uid, 11, buttonHandler(handler))
+	}
+
+}
+
+// CallbackReleased is a convenience function for registering
+// a handler to be called when the button is released.
+func (e *RotaryEncoder) CallbackReleased(handler func()) {
+
+	if handler == nil {
+		e.callbacks.Set(e.t, e.uid, 12, nil)
+	} else {
+		e.callbacks.Set(e.t, e.uid, 12, buttonHandler(handler))
+	}
+
+}
@@ -0,0 +1,35 @@
+package rotaryencoder
+
+import (
+	"testing"
+
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+func TestGetCount(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	e, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Respond(e.uid, 1, int32(-42)); err != nil {
+		t.Fatal(err)
+	}
+
+	// The reset flag is packed as a single byte
+	getCount := func(reset bool) error {
+		count, err := e.GetCount(reset)
+		if err == nil && count != -42 {
+			t.Errorf("GetCount(%t) = %d, want -42", reset, count)
+		}
+		return err
+	}
+
+	m.Expect(t, e.uid, []tinkerforgetest.Expectation{
+		{Name: "GetCount(true)", Call: func() error { return getCount(true) }, FuncID: 1, Payload: []byte{1}},
+		{Name: "GetCount(false)", Call: func() error { return getCount(false) }, FuncID: 1, Payload: []byte{0}},
+	})
+}
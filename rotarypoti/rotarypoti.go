@@ -0,0 +1,146 @@
+// Package rotarypoti has control routines for the Rotary Poti Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package rotarypoti
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// RotaryPoti is a control structure for Rotary Poti Bricklets
+type RotaryPoti struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+// New creates a new rotary poti control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*RotaryPoti, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &RotaryPoti{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// GetPosition returns the position of the poti (-150 to 150, 0 is the middle position).
+func (r *RotaryPoti) GetPosition() (int16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(r.uid, 1, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := r.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the position
+	var position int16
+	if err = res.Decode(&position); err != nil {
+		return 0, err
+	}
+
+	return position, nil
+}
+
+// GetAnalogValue returns the raw value of the analog-digital converter (0 to 4095).
+func (r *RotaryPoti) GetAnalogValue() (uint16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(r.uid, 2, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := r.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the value
+	var value uint16
+	if err = res.Decode(&value); err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+// SetPositionCallbackPeriod sets the period in ms with which the position callback is triggered.
+// The callback is only triggered if the position changed. A value of 0 turns the callback off.
+func (r *RotaryPoti) SetPositionCallbackPeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(r.uid, 3, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = r.t.Send(p)
+	return err
+}
+
+// SetPositionCallbackThreshold sets the threshold for the position reached callback.
+// 'option' is one of the helpers.Threshold* constants.
+func (r *RotaryPoti) SetPositionCallbackThreshold(option byte, min, max int16) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(r.uid, 7, false, option, min, max)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = r.t.Send(p)
+	return err
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (r *RotaryPoti) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(r.t, r.uid)
+	return i, err
+}
+
+type positionHandler func(int16)
+
+func (f positionHandler) Handle(p *tinkerforge.Packet) {
+
+	var position int16
+
+	if p.Decode(&position) != nil {
+		return
+	}
+	f(position)
+
+}
+
+// CallbackPosition is a convenience function for registering
+// a handler to be called periodically with the position.
+// The period is set with SetPositionCallbackPeriod.
+func (r *RotaryPoti) CallbackPosition(handler func(int16)) {
+
+	if handler == nil {
+		r.callbacks.Set(r.t, r.uid, 13, nil)
+	} else {
+		r.callbacks.Set(r.t, r.uid, 13, positionHandler(handler))
+	}
+
+}
+
+// CallbackPositionReached is a convenience function for registering
+// a handler to be called when the threshold set by SetPositionCallbackThreshold is reached.
+func (r *RotaryPoti) CallbackPositionReached(handler func(int16)) {
+
+	if handler == nil {
+		r.callbacks.Set(r.t, r.uid, 15, nil)
+	} else {
+		r.callbacks.Set(r.t, r.uid, 15, positionHandler(handler))
+	}
+
+}
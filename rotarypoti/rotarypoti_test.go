@@ -0,0 +1,104 @@
+package rotarypoti
+
+import (
+	"testing"
+	"time"
+
+	"github.com/noxer/tinkerforge/helpers"
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+func TestWire(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	r, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.Expect(t, r.uid, []tinkerforgetest.Expectation{
+		{Name: "SetPositionCallbackPeriod", Call: func() error { return r.SetPositionCallbackPeriod(50) }, FuncID: 3, Payload: []byte{50, 0, 0, 0}},
+		{Name: "SetPositionCallbackThreshold", Call: func() error { return r.SetPositionCallbackThreshold(helpers.ThresholdInside, -150, -10) }, FuncID: 7, Payload: []byte{'i', 0x6a, 0xff, 0xf6, 0xff}},
+	})
+}
+
+func TestGetPosition(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	r, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []int16{-150, -1, 0, 1, 150} {
+		if err := m.Respond(r.uid, 1, want); err != nil {
+			t.Fatal(err)
+		}
+
+		position, err := r.GetPosition()
+		if err != nil || position != want {
+			t.Errorf("GetPosition() = %d, %v, want %d", position, err, want)
+		}
+	}
+}
+
+func TestGetAnalogValue(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	r, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Respond(r.uid, 2, uint16(4095)); err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := r.GetAnalogValue()
+	if err != nil || value != 4095 {
+		t.Errorf("GetAnalogValue() = %d, %v, want 4095", value, err)
+	}
+}
+
+func TestCallbacks(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	r, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	position := make(chan int16, 1)
+	reached := make(chan int16, 1)
+	r.CallbackPosition(func(value int16) { position <- value })
+	r.CallbackPositionReached(func(value int16) { reached <- value })
+
+	if err := m.Fire(r.uid, 13, int16(-42)); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Fire(r.uid, 15, int16(-150)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		name string
+		got  chan int16
+		want int16
+	}{
+		{"position", position, -42},
+		{"position reached", reached, -150},
+	} {
+		select {
+		case value := <-c.got:
+			if value != c.want {
+				t.Errorf("%s callback got %d, want %d", c.name, value, c.want)
+			}
+		case <-time.After(time.Second):
+			t.Errorf("%s callback was not called", c.name)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+// Package segmentdisplay4x7 has control routines for the Segment Display 4x7 Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package segmentdisplay4x7
+
+import (
+	"errors"
+
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// SegmentDisplay4x7 is a control structure for Segment Display 4x7 Bricklets
+type SegmentDisplay4x7 struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+var (
+	// Digits holds the segment patterns of the decimal digits 0 to 9
+	Digits = [10]uint8{0x3f, 0x06, 0x5b, 0x4f, 0x66, 0x6d, 0x7d, 0x07, 0x7f, 0x6f}
+	// Minus is the segment pattern of a minus sign
+	Minus uint8 = 0x40
+
+	// ErrOutOfRange is returned by SetNumber for numbers that don't fit the display
+	ErrOutOfRange = errors.New("Number does not fit the display")
+)
+
+// New creates a new segment display control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*SegmentDisplay4x7, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &SegmentDisplay4x7{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// SetSegments sets the segments of the four digits (bit 0 to 6 for the segments a to g),
+// the brightness (0 to 7) and whether the colon is lit.
+func (s *SegmentDisplay4x7) SetSegments(segments [4]uint8, brightness uint8, colon bool) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(s.uid, 1, false, segments, brightness, colon)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = s.t.Send(p)
+	return err
+}
+
+// GetSegments returns the segments, brightness and colon state as set by SetSegments.
+func (s *SegmentDisplay4x7) GetSegments() (segments [4]uint8, brightness uint8, colon bool, err error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(s.uid, 2, true)
+	if err != nil {
+		return segments, 0, false, err
+	}
+
+	// Send the packet
+	res, err := s.t.Send(p)
+	if err != nil {
+		return segments, 0, false, err
+	}
+
+	// Decode the segments
+	if err = res.Decode(&segments, &brightness, &colon); err != nil {
+		return [4]uint8{}, 0, false, err
+	}
+
+	return segments, brightness, colon, nil
+}
+
+// SetNumber shows 'n' (-999 to 9999) right aligned on the display with 'brightness' (0 to 7).
+func (s *SegmentDisplay4x7) SetNumber(n int, brightness uint8) error {
+	if n < -999 || n > 9999 {
+		return ErrOutOfRange
+	}
+
+	negative := n < 0
+	if negative {
+		n = -n
+	}
+
+	// Fill the digits from the right
+	var segments [4]uint8
+	i := 3
+	for {
+		segments[i] = Digits[n%10]
+		n /= 10
+		if n == 0 {
+			break
+		}
+		i--
+	}
+
+	if negative {
+		segments[i-1] = Minus
+	}
+
+	return s.SetSegments(segments, brightness, false)
+}
+
+// StartCounter starts a counter from 'valueFrom' to 'valueTo' (-999 to 9999)
+// changing by 'increment' every 'length' ms.
+func (s *SegmentDisplay4x7) StartCounter(valueFrom, valueTo, increment int16, length uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(s.uid, 3, false, valueFrom, valueTo, increment, length)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = s.t.Send(p)
+	return err
+}
+
+// GetCounterValue returns the current value of the counter.
+func (s *SegmentDisplay4x7) GetCounterValue() (uint16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(s.uid, 4, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := s.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the counter value
+	var value uint16
+	if err = res.Decode(&value); err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (s *SegmentDisplay4x7) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(s.t, s.uid)
+	return i, err
+}
+
+type counterFinishedHandler func()
+
+func (f counterFinishedHandler) Handle(p *tinkerforge.Packet) {
+	f()
+}
+
+// CallbackCounterFinished is a convenience function for registering
+// a handler to be called when a counter started with StartCounter finished.
+func (s *SegmentDisplay4x7) CallbackCounterFinished(handler func()) {
+
+	if handler == nil {
+		s.callbacks.Set(s.t, s.uid, 5, nil)
+	} else {
+		s.callbacks.Set(s.t, s.uid, 5, counterFinishedHandler(handler))
+	}
+
+}
@@ -0,0 +1,159 @@
+// Package servo has control routines for the Servo Brick
+// Author: Tim Scheuermann (https://github.com/noxer)
+package servo
+
+import (
+	"errors"
+
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// Servo is a control structure for Servo Bricks
+type Servo struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+const (
+	// Ports is the number of servo ports (0 to 6) of the brick
+	Ports = 7
+	// PortMask can be or'ed with a bitmask of ports to address several ports at once
+	PortMask = 1 << 7
+)
+
+var (
+	// ErrInvalidPort is returned for a port number outside of 0 to 6 without PortMask set
+	ErrInvalidPort = errors.New("Invalid servo port")
+)
+
+// New creates a new servo control for the brick with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*Servo, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &Servo{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// checkPort validates a port number or port bitmask.
+func checkPort(port uint8) error {
+	if port&PortMask == 0 && port >= Ports {
+		return ErrInvalidPort
+	}
+
+	return nil
+}
+
+// send validates 'port' and sends a packet without an expected response.
+func (s *Servo) send(funcID uint8, port uint8, params ...interface{}) error {
+	if err := checkPort(port); err != nil {
+		return err
+	}
+
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(s.uid, funcID, false, append([]interface{}{port}, params...)...)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = s.t.Send(p)
+	return err
+}
+
+// Enable enables the servo on 'port'.
+func (s *Servo) Enable(port uint8) error {
+	return s.send(1, port)
+}
+
+// Disable disables the servo on 'port'.
+func (s *Servo) Disable(port uint8) error {
+	return s.send(2, port)
+}
+
+// SetPosition sets the position of the servo on 'port' in °/100.
+func (s *Servo) SetPosition(port uint8, position int16) error {
+	return s.send(4, port, position)
+}
+
+// GetPosition returns the position of the servo on 'port' as set by SetPosition.
+func (s *Servo) GetPosition(port uint8) (int16, error) {
+	if port >= Ports {
+		return 0, ErrInvalidPort
+	}
+
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(s.uid, 5, true, port)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := s.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the position
+	var position int16
+	if err = res.Decode(&position); err != nil {
+		return 0, err
+	}
+
+	return position, nil
+}
+
+// SetVelocity sets the maximum velocity of the servo on 'port' in °/100s.
+func (s *Servo) SetVelocity(port uint8, velocity uint16) error {
+	return s.send(7, port, velocity)
+}
+
+// SetAcceleration sets the acceleration of the servo on 'port' in °/100s².
+func (s *Servo) SetAcceleration(port uint8, acceleration uint16) error {
+	return s.send(10, port, acceleration)
+}
+
+// SetPulseWidth sets the minimum and maximum pulse width of the servo on 'port' in µs.
+func (s *Servo) SetPulseWidth(port uint8, min, max uint16) error {
+	return s.send(14, port, min, max)
+}
+
+// GetIdentity returns the position information of the brick and its identifier.
+func (s *Servo) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(s.t, s.uid)
+	return i, err
+}
+
+type positionReachedHandler func(uint8, int16)
+
+func (f positionReachedHandler) Handle(p *tinkerforge.Packet) {
+
+	var (
+		port     uint8
+		position int16
+	)
+
+	if p.Decode(&port, &position) != nil {
+		return
+	}
+	f(port, position)
+
+}
+
+// CallbackPositionReached is a convenience function for registering
+// a handler to be called when a servo reached its set position.
+func (s *Servo) CallbackPositionReached(handler func(port uint8, position int16)) {
+
+	if handler == nil {
+		s.callbacks.Set(s.t, s.uid, 27, nil)
+	} else {
+		s.callbacks.Set(s.t, s.uid, 27, positionReachedHandler(handler))
+	}
+
+}
@@ -0,0 +1,131 @@
+// Package solidstaterelay has control routines for the Solid State Relay Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package solidstaterelay
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// SolidStateRelay is a control structure for Solid State Relay Bricklets
+type SolidStateRelay struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+// New creates a new solid state relay control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*SolidStateRelay, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &SolidStateRelay{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// SetState switches the relay on (true) or off (false).
+// A running monoflop is aborted.
+func (s *SolidStateRelay) SetState(state bool) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(s.uid, 1, false, state)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = s.t.Send(p)
+	return err
+}
+
+// GetState returns the current state of the relay.
+func (s *SolidStateRelay) GetState() (bool, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(s.uid, 2, true)
+	if err != nil {
+		return false, err
+	}
+
+	// Send the packet
+	res, err := s.t.Send(p)
+	if err != nil {
+		return false, err
+	}
+
+	// Decode the state
+	var state bool
+	if err = res.Decode(&state); err != nil {
+		return false, err
+	}
+
+	return state, nil
+}
+
+// SetMonoflop switches the relay to 'state' and back after 'time' ms.
+func (s *SolidStateRelay) SetMonoflop(state bool, time uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(s.uid, 3, false, state, time)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = s.t.Send(p)
+	return err
+}
+
+// GetMonoflop returns the state, the configured time and the remaining time in ms of a running monoflop.
+func (s *SolidStateRelay) GetMonoflop() (state bool, time, timeRemaining uint32, err error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(s.uid, 4, true)
+	if err != nil {
+		return false, 0, 0, err
+	}
+
+	// Send the packet
+	res, err := s.t.Send(p)
+	if err != nil {
+		return false, 0, 0, err
+	}
+
+	// Decode the monoflop
+	if err = res.Decode(&state, &time, &timeRemaining); err != nil {
+		return false, 0, 0, err
+	}
+
+	return state, time, timeRemaining, nil
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (s *SolidStateRelay) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(s.t, s.uid)
+	return i, err
+}
+
+type monoflopDoneHandler func(bool)
+
+func (f monoflopDoneHandler) Handle(p *tinkerforge.Packet) {
+
+	var state bool
+
+	if p.Decode(&state) != nil {
+		return
+	}
+	f(state)
+
+}
+
+// CallbackMonoflopDone is a convenience function for registering
+// a handler to be called when a monoflop timer ran out.
+func (s *SolidStateRelay) CallbackMonoflopDone(handler func(state bool)) {
+
+	if handler == nil {
+		s.callbacks.Set(s.t, s.uid, 5, nil)
+	} else {
+		s.callbacks.Set(s.t, s.uid, 5, monoflopDoneHandler(handler))
+	}
+
+}
@@ -0,0 +1,88 @@
+package solidstaterelay
+
+import (
+	"testing"
+	"time"
+
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+func TestWire(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	s, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.Expect(t, s.uid, []tinkerforgetest.Expectation{
+		{Name: "SetState", Call: func() error { return s.SetState(true) }, FuncID: 1, Payload: []byte{1}},
+		{Name: "SetMonoflop", Call: func() error { return s.SetMonoflop(true, 1500) }, FuncID: 3, Payload: []byte{1, 0xdc, 0x05, 0, 0}},
+	})
+}
+
+func TestGetState(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	s, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Respond(s.uid, 2, true); err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := s.GetState()
+	if err != nil || !state {
+		t.Errorf("GetState() = %t, %v, want true", state, err)
+	}
+}
+
+func TestGetMonoflop(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	s, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A bool followed by two uint32 values
+	if err := m.Respond(s.uid, 4, true, uint32(1500), uint32(700)); err != nil {
+		t.Fatal(err)
+	}
+
+	state, total, remaining, err := s.GetMonoflop()
+	if err != nil || !state || total != 1500 || remaining != 700 {
+		t.Errorf("GetMonoflop() = %t, %d, %d, %v, want true, 1500, 700", state, total, remaining, err)
+	}
+}
+
+func TestCallbackMonoflopDone(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	s, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(chan bool, 1)
+	s.CallbackMonoflopDone(func(state bool) { got <- state })
+
+	if err := m.Fire(s.uid, 5, true); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case state := <-got:
+		if !state {
+			t.Error("got state false, want true")
+		}
+	case <-time.After(time.Second):
+		t.Error("monoflop done callback was not called")
+	}
+}
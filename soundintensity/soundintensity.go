@@ -0,0 +1,124 @@
+// Package soundintensity has control routines for the Sound Intensity Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package soundintensity
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// SoundIntensity is a control structure for Sound Intensity Bricklets
+type SoundIntensity struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+// New creates a new sound intensity control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*SoundIntensity, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &SoundIntensity{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// GetIntensity returns the sound intensity (0 to 4095).
+// The value is not in a unit, larger values mean louder sounds.
+func (s *SoundIntensity) GetIntensity() (uint16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(s.uid, 1, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := s.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the intensity
+	var intensity uint16
+	if err = res.Decode(&intensity); err != nil {
+		return 0, err
+	}
+
+	return intensity, nil
+}
+
+// SetIntensityCallbackPeriod sets the period in ms with which the intensity callback is triggered.
+// The callback is only triggered if the intensity changed. A value of 0 turns the callback off.
+func (s *SoundIntensity) SetIntensityCallbackPeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(s.uid, 2, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = s.t.Send(p)
+	return err
+}
+
+// SetIntensityCallbackThreshold sets the threshold for the intensity reached callback.
+// 'option' is one of the helpers.Threshold* constants.
+func (s *SoundIntensity) SetIntensityCallbackThreshold(option byte, min, max uint16) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(s.uid, 4, false, option, min, max)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = s.t.Send(p)
+	return err
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (s *SoundIntensity) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(s.t, s.uid)
+	return i, err
+}
+
+type intensityHandler func(uint16)
+
+func (f intensityHandler) Handle(p *tinkerforge.Packet) {
+
+	var intensity uint16
+
+	if p.Decode(&intensity) != nil {
+		return
+	}
+	f(intensity)
+
+}
+
+// CallbackIntensity is a convenience function for registering
+// a handler to be called periodically with the intensity.
+// The period is set with SetIntensityCallbackPeriod.
+func (s *SoundIntensity) CallbackIntensity(handler func(uint16)) {
+
+	if handler == nil {
+		s.callbacks.Set(s.t, s.uid, 8, nil)
+	} else {
+		s.callbacks.Set(s.t, s.uid, 8, intensityHandler(handler))
+	}
+
+}
+
+// CallbackIntensityReached is a convenience function for registering
+// a handler to be called when the threshold set by SetIntensityCallbackThreshold is reached.
+func (s *SoundIntensity) CallbackIntensityReached(handler func(uint16)) {
+
+	if handler == nil {
+		s.callbacks.Set(s.t, s.uid, 9, nil)
+	} else {
+		s.callbacks.Set(s.t, s.uid, 9, intensityHandler(handler))
+	}
+
+}
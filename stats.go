@@ -0,0 +1,32 @@
+package tinkerforge
+
+import "sync/atomic"
+
+// Stats is a snapshot of the client's packet counters
+type Stats struct {
+	Sent      uint64 // Packets written to the connection
+	Received  uint64 // Well-formed packets read from the connection
+	Timeouts  uint64 // Requests whose response timed out
+	Callbacks uint64 // Callbacks dispatched to the handlers
+	Dropped   uint64 // Callbacks dropped because the handlers were too slow (see SetDropCallbacks)
+}
+
+// stats holds the counters, they are accessed atomically
+type stats struct {
+	sent      uint64
+	received  uint64
+	timeouts  uint64
+	callbacks uint64
+	dropped   uint64
+}
+
+// Stats returns a snapshot of the packet counters
+func (t *tinkerforge) Stats() Stats {
+	return Stats{
+		Sent:      atomic.LoadUint64(&t.stats.sent),
+		Received:  atomic.LoadUint64(&t.stats.received),
+		Timeouts:  atomic.LoadUint64(&t.stats.timeouts),
+		Callbacks: atomic.LoadUint64(&t.stats.callbacks),
+		Dropped:   atomic.LoadUint64(&t.stats.dropped),
+	}
+}
@@ -0,0 +1,155 @@
+// Package stepper has control routines for the Stepper Brick
+// Author: Tim Scheuermann (https://github.com/noxer)
+package stepper
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// Stepper is a control structure for Stepper Bricks
+type Stepper struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+// New creates a new stepper control for the brick with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*Stepper, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &Stepper{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// send sends a packet for function 'funcID' without an expected response.
+func (s *Stepper) send(funcID uint8, params ...interface{}) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(s.uid, funcID, false, params...)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = s.t.Send(p)
+	return err
+}
+
+// SetMaxVelocity sets the maximum velocity of the motor in steps per second.
+func (s *Stepper) SetMaxVelocity(velocity uint16) error {
+	return s.send(1, velocity)
+}
+
+// SetSpeedRamping sets the acceleration and deacceleration of the motor in steps/s².
+func (s *Stepper) SetSpeedRamping(accel, deaccel uint16) error {
+	return s.send(4, accel, deaccel)
+}
+
+// SetSteps sets the number of steps the motor should run. Negative values run backwards.
+func (s *Stepper) SetSteps(steps int32) error {
+	return s.send(11, steps)
+}
+
+// GetRemainingSteps returns the number of steps remaining from the last call of SetSteps.
+func (s *Stepper) GetRemainingSteps() (int32, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(s.uid, 13, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := s.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the steps
+	var steps int32
+	if err = res.Decode(&steps); err != nil {
+		return 0, err
+	}
+
+	return steps, nil
+}
+
+// DriveForward drives the motor forward until Stop or DriveBackward is called.
+func (s *Stepper) DriveForward() error {
+	return s.send(16)
+}
+
+// DriveBackward drives the motor backward until Stop or DriveForward is called.
+func (s *Stepper) DriveBackward() error {
+	return s.send(17)
+}
+
+// Stop stops the motor using the deacceleration set by SetSpeedRamping.
+func (s *Stepper) Stop() error {
+	return s.send(18)
+}
+
+// SetMotorCurrent sets the current with which the motor will be driven in mA.
+func (s *Stepper) SetMotorCurrent(current uint16) error {
+	return s.send(22, current)
+}
+
+// GetIdentity returns the position information of the brick and its identifier.
+func (s *Stepper) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(s.t, s.uid)
+	return i, err
+}
+
+type underVoltageHandler func(uint16)
+
+func (f underVoltageHandler) Handle(p *tinkerforge.Packet) {
+
+	var voltage uint16
+
+	if p.Decode(&voltage) != nil {
+		return
+	}
+	f(voltage)
+
+}
+
+// CallbackUnderVoltage is a convenience function for registering
+// a handler to be called when the input voltage drops below the minimum voltage.
+func (s *Stepper) CallbackUnderVoltage(handler func(uint16)) {
+
+	if handler == nil {
+		s.callbacks.Set(s.t, s.uid, 31, nil)
+	} else {
+		s.callbacks.Set(s.t, s.uid, 31, underVoltageHandler(handler))
+	}
+
+}
+
+type positionReachedHandler func(int32)
+
+func (f positionReachedHandler) Handle(p *tinkerforge.Packet) {
+
+	var position int32
+
+	if p.Decode(&position) != nil {
+		return
+	}
+	f(position)
+
+}
+
+// CallbackPositionReached is a convenience function for registering
+// a handler to be called when a position set by SetSteps is reached.
+func (s *Stepper) CallbackPositionReached(handler func(int32)) {
+
+	if handler == nil {
+		s.callbacks.Set(s.t, s.uid, 32, nil)
+	} else {
+		s.callbacks.Set(s.t, s.uid, 32, positionReachedHandler(handler))
+	}
+
+}
@@ -0,0 +1,87 @@
+package stepper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+func TestWire(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	s, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.Expect(t, s.uid, []tinkerforgetest.Expectation{
+		{Name: "SetMaxVelocity", Call: func() error { return s.SetMaxVelocity(65535) }, FuncID: 1, Payload: []byte{0xff, 0xff}},
+		{Name: "SetSpeedRamping", Call: func() error { return s.SetSpeedRamping(1000, 40000) }, FuncID: 4, Payload: []byte{0xe8, 0x03, 0x40, 0x9c}},
+		{Name: "SetSteps forward", Call: func() error { return s.SetSteps(100000) }, FuncID: 11, Payload: []byte{0xa0, 0x86, 0x01, 0x00}},
+		{Name: "SetSteps backward", Call: func() error { return s.SetSteps(-2) }, FuncID: 11, Payload: []byte{0xfe, 0xff, 0xff, 0xff}},
+		{Name: "Stop", Call: s.Stop, FuncID: 18, Payload: []byte{}},
+	})
+}
+
+func TestGetRemainingSteps(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	s, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []int32{-100000, 0, 2147483647} {
+		if err := m.Respond(s.uid, 13, want); err != nil {
+			t.Fatal(err)
+		}
+
+		steps, err := s.GetRemainingSteps()
+		if err != nil || steps != want {
+			t.Errorf("GetRemainingSteps() = %d, %v, want %d", steps, err, want)
+		}
+	}
+}
+
+func TestCallbacks(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	s, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	voltage := make(chan uint16, 1)
+	position := make(chan int32, 1)
+	s.CallbackUnderVoltage(func(v uint16) { voltage <- v })
+	s.CallbackPositionReached(func(p int32) { position <- p })
+
+	if err := m.Fire(s.uid, 31, uint16(60000)); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Fire(s.uid, 32, int32(-12345)); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case v := <-voltage:
+		if v != 60000 {
+			t.Errorf("under voltage callback got %d, want 60000", v)
+		}
+	case <-time.After(time.Second):
+		t.Error("under voltage callback was not called")
+	}
+
+	select {
+	case p := <-position:
+		if p != -12345 {
+			t.Errorf("position reached callback got %d, want -12345", p)
+		}
+	case <-time.After(time.Second):
+		t.Error("position reached callback was not called")
+	}
+}
@@ -0,0 +1,144 @@
+// Package temperature has control routines for the Temperature Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package temperature
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// Temperature is a control structure for Temperature Bricklets
+type Temperature struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+const (
+	// I2CModeFast uses a 400kHz I2C clock (default)
+	I2CModeFast uint8 = 0
+	// I2CModeSlow uses a 100kHz I2C clock
+	I2CModeSlow uint8 = 1
+)
+
+// New creates a new temperature control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*Temperature, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &Temperature{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// GetTemperature returns the temperature of the sensor in °C/100.
+func (tb *Temperature) GetTemperature() (int16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(tb.uid, 1, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := tb.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the temperature
+	var temperature int16
+	if err = res.Decode(&temperature); err != nil {
+		return 0, err
+	}
+
+	return temperature, nil
+}
+
+// SetTemperatureCallbackPeriod sets the period in ms with which the temperature callback is triggered.
+// The callback is only triggered if the temperature changed. A value of 0 turns the callback off.
+func (tb *Temperature) SetTemperatureCallbackPeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(tb.uid, 2, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = tb.t.Send(p)
+	return err
+}
+
+// SetTemperatureCallbackThreshold sets the threshold for the temperature reached callback.
+// 'option' is one of the helpers.Threshold* constants.
+func (tb *Temperature) SetTemperatureCallbackThreshold(option byte, min, max int16) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(tb.uid, 4, false, option, min, max)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = tb.t.Send(p)
+	return err
+}
+
+// SetI2CMode sets the I2C mode (I2CModeFast or I2CModeSlow).
+// Use the slow mode if the bricklet is connected with a long cable.
+func (tb *Temperature) SetI2CMode(mode uint8) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(tb.uid, 10, false, mode)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = tb.t.Send(p)
+	return err
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (tb *Temperature) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(tb.t, tb.uid)
+	return i, err
+}
+
+type temperatureHandler func(int16)
+
+func (f temperatureHandler) Handle(p *tinkerforge.Packet) {
+
+	var temperature int16
+
+	if p.Decode(&temperature) != nil {
+		return
+	}
+	f(temperature)
+
+}
+
+// CallbackTemperature is a convenience function for registering
+// a handler to be called periodically with the temperature.
+// The period is set with SetTemperatureCallbackPeriod.
+func (tb *Temperature) CallbackTemperature(handler func(int16)) {
+
+	if handler == nil {
+		tb.callbacks.Set(tb.t, tb.uid, 8, nil)
+	} else {
+		tb.callbacks.Set(tb.t, tb.uid, 8, temperatureHandler(handler))
+	}
+
+}
+
+// CallbackTemperatureReached is a convenience function for registering
+// a handler to be called when the threshold set by SetTemperatureCallbackThreshold is reached.
+func (tb *Temperature) CallbackTemperatureReached(handler func(int16)) {
+
+	if handler == nil {
+		tb.callbacks.Set(tb.t, tb.uid, 9, nil)
+	} else {
+		tb.callbacks.Set(tb.t, tb.uid, 9, temperatureHandler(handler))
+	}
+
+}
@@ -0,0 +1,185 @@
+// Package temperatureir has control routines for the Temperature IR Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package temperatureir
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// TemperatureIR is a control structure for Temperature IR Bricklets
+type TemperatureIR struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+// New creates a new temperature IR control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*TemperatureIR, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &TemperatureIR{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// GetAmbientTemperature returns the ambient temperature of the sensor in °C/10.
+func (tb *TemperatureIR) GetAmbientTemperature() (int16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(tb.uid, 1, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := tb.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the ambient temperature
+	var temperature int16
+	if err = res.Decode(&temperature); err != nil {
+		return 0, err
+	}
+
+	return temperature, nil
+}
+
+// GetObjectTemperature returns the temperature of the object in front of the sensor in °C/10.
+// The value depends on the emissivity of the object, see SetEmissivity.
+func (tb *TemperatureIR) GetObjectTemperature() (int16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(tb.uid, 2, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := tb.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the object temperature
+	var temperature int16
+	if err = res.Decode(&temperature); err != nil {
+		return 0, err
+	}
+
+	return temperature, nil
+}
+
+// SetEmissivity sets the emissivity of the measured object as a multiple of 1/65535.
+// For example an emissivity of 0.1 is set with 6553.
+func (tb *TemperatureIR) SetEmissivity(emissivity uint16) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(tb.uid, 3, false, emissivity)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = tb.t.Send(p)
+	return err
+}
+
+// GetEmissivity returns the emissivity as set by SetEmissivity.
+func (tb *TemperatureIR) GetEmissivity() (uint16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(tb.uid, 4, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := tb.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the emissivity
+	var emissivity uint16
+	if err = res.Decode(&emissivity); err != nil {
+		return 0, err
+	}
+
+	return emissivity, nil
+}
+
+// SetAmbientTemperatureCallbackPeriod sets the period in ms with which the ambient temperature callback is triggered.
+// The callback is only triggered if the temperature changed. A value of 0 turns the callback off.
+func (tb *TemperatureIR) SetAmbientTemperatureCallbackPeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(tb.uid, 5, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = tb.t.Send(p)
+	return err
+}
+
+// SetObjectTemperatureCallbackPeriod sets the period in ms with which the object temperature callback is triggered.
+// The callback is only triggered if the temperature changed. A value of 0 turns the callback off.
+func (tb *TemperatureIR) SetObjectTemperatureCallbackPeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(tb.uid, 7, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = tb.t.Send(p)
+	return err
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (tb *TemperatureIR) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(tb.t, tb.uid)
+	return i, err
+}
+
+type temperatureHandler func(int16)
+
+func (f temperatureHandler) Handle(p *tinkerforge.Packet) {
+
+	var temperature int16
+
+	if p.Decode(&temperature) != nil {
+		return
+	}
+	f(temperature)
+
+}
+
+// CallbackAmbientTemperature is a convenience function for registering
+// a handler to be called periodically with the ambient temperature.
+// The period is set with SetAmbientTemperatureCallbackPeriod.
+func (tb *TemperatureIR) CallbackAmbientTemperature(handler func(int16)) {
+
+	if handler == nil {
+		tb.callbacks.Set(tb.t, tb.uid, 15, nil)
+	} else {
+		tb.callbacks.Set(tb.t, tb.uid, 15, temperatureHandler(handler))
+	}
+
+}
+
+// CallbackObjectTemperature is a convenience function for registering
+// a handler to be called periodically with the object temperature.
+// The period is set with SetObjectTemperatureCallbackPeriod.
+func (tb *TemperatureIR) CallbackObjectTemperature(handler func(int16)) {
+
+	if handler == nil {
+		tb.callbacks.Set(tb.t, tb.uid, 16, nil)
+	} else {
+		tb.callbacks.Set(tb.t, tb.uid, 16, temperatureHandler(handler))
+	}
+
+}
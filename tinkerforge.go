@@ -4,23 +4,30 @@ package tinkerforge
 
 import (
 	"bufio"
+	"context"
 	"errors"
-	"fmt"
 	"io"
 	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
-// Handler to get callbacks
+// Handler to get callbacks. Callbacks are delivered on worker go routines, so handlers
+// registered for different packets may run concurrently. Callbacks for the same
+// uid and function ID are delivered in order. Handlers may call Send.
+// While the queue of a worker is full, the receiver waits for the handlers to catch up,
+// so responses are delayed as well (a handler waiting for a response may time out).
+// Dropping callbacks instead of waiting is enabled with SetDropCallbacks.
 type Handler interface {
 	Handle(packet *Packet)
 }
 
 // respHandler for getting responses back
 type respHandler struct {
-	c chan *Packet
-	t time.Duration
+	c    chan *Packet
+	t    time.Duration
+	lost chan struct{} // closed if the response can't arrive anymore
 }
 
 // Handles responses
@@ -31,6 +38,13 @@ func (r respHandler) Handle(p *Packet) {
 		return
 	}
 
+	// The channel buffers the response, only wait if it is still full
+	select {
+	case r.c <- p:
+		return
+	default:
+	}
+
 	timer := time.NewTimer(r.t)
 
 	select {
@@ -44,23 +58,61 @@ func (r respHandler) Handle(p *Packet) {
 // Tinkerforge interface
 type Tinkerforge interface {
 	io.Closer
-	Handler(uid uint32, funcID uint8, handler Handler)
+	Handler(uid uint32, funcID uint8, handler Handler) HandlerToken
+	HandlerAny(funcID uint8, handler Handler) HandlerToken
+	RemoveHandler(token HandlerToken)
 	Send(packet *Packet) (*Packet, error)
+	SendContext(ctx context.Context, packet *Packet) (*Packet, error)
+	SendRetry(packet *Packet, attempts int, backoff time.Duration) (*Packet, error)
+	Broadcast(packet *Packet) error
+	SetReconnect(enabled bool, backoff time.Duration)
+	SetConnectionStateHandler(handler func(connected bool))
+	SetTimeout(d time.Duration)
+	SetDropCallbacks(enabled bool)
+	SetLogger(l Logger)
+	Stats() Stats
+	Timeout() time.Duration
+	Authenticate(secret string) error
+	Enumerate() error
+	EnumerateCallback(handler func(e Enumeration))
 }
 
 // Tinkerforge structure
 type tinkerforge struct {
-	conn          io.ReadWriteCloser
-	seqNum        chan byte
-	handlers      map[handlerID]Handler
+	timeout int64 // time.Duration, accessed atomically (first for 64 bit alignment)
+	stats   stats // counters, accessed atomically
+
+	dropCallbacks int32 // bool, accessed atomically
+
+	host      string
+	conn      io.ReadWriteCloser
+	connMutex sync.RWMutex
+
+	reconnect bool
+	backoff   time.Duration
+	stateFunc func(connected bool)
+	secret    *string // secret of the last successful Authenticate
+
+	stateQueue  []bool // connection state changes for the state worker
+	stateMutex  sync.Mutex
+	stateSignal chan struct{}
+	logger      Logger
+
+	seqNums       chan byte // free sequence numbers for requests expecting a response
+	nextSeqNum    byte      // sequence number for requests without response (sender only)
+	handlers      map[handlerID][]registeredHandler
 	handlersMutex sync.RWMutex
+	nextHandlerID uint64
 
-	sendQueue chan func()
+	enumerateToken HandlerToken // handler registered with EnumerateCallback
+	enumerateMutex sync.Mutex
 
-	done chan struct{}
-	wait sync.WaitGroup
+	sendQueue chan func()
+	callbacks []chan *Packet
 
-	Timeout time.Duration
+	done      chan struct{}
+	closeOnce sync.Once
+	wait      sync.WaitGroup
 }
 
 type handlerID struct {
@@ -69,9 +121,36 @@ type handlerID struct {
 	seqNum uint8
 }
 
+// registeredHandler is a handler with its registration number
+type registeredHandler struct {
+	id      uint64
+	handler Handler
+}
+
+// HandlerToken identifies a handler registered with Handler, it is used to remove the handler again
+type HandlerToken struct {
+	key handlerID
+	id  uint64
+}
+
+const (
+	// maxSeqNum is the highest sequence number (4 bit, 0 is reserved for callbacks)
+	maxSeqNum = 15
+
+	// callbackWorkers is the number of go routines delivering callbacks
+	callbackWorkers = 4
+	// callbackQueue is the number of callbacks buffered per worker, the receiver waits
+	// while the queue is full unless dropping callbacks is enabled (see SetDropCallbacks)
+	callbackQueue = 32
+)
+
 var (
 	// ErrTimeout represents a timeout while waiting for a callback
 	ErrTimeout = errors.New("Timeout while waiting for callback")
+	// ErrClosed is returned when the client is used after Close was called
+	ErrClosed = errors.New("Connection is closed")
+	// ErrConnectionLost is returned when the connection was lost while waiting for a response
+	ErrConnectionLost = errors.New("Connection lost while waiting for response")
 )
 
 // New creates a new tinkerforge client
@@ -81,46 +160,85 @@ func New(host string) (Tinkerforge, error) {
 		host = "localhost:4223"
 	}
 
-	// Resolve service address
-	addr, err := net.ResolveTCPAddr("tcp", host)
-	if err != nil {
-		return nil, err
-	}
-
 	// Connect to service
-	conn, err := net.DialTCP("tcp", nil, addr)
+	conn, err := dial(host)
 	if err != nil {
 		return nil, err
 	}
 
+	return newClient(conn, host), nil
+}
+
+// NewWithConn creates a new tinkerforge client communicating over conn.
+// Clients created this way can't reconnect automatically.
+func NewWithConn(conn io.ReadWriteCloser) Tinkerforge {
+	return newClient(conn, "")
+}
+
+// newClient sets up the client structure and starts the go routines
+func newClient(conn io.ReadWriteCloser, host string) *tinkerforge {
 	// Build up structure
 	tf := &tinkerforge{
-		conn:      conn,
-		seqNum:    make(chan byte, 8),
-		handlers:  make(map[handlerID]Handler),
-		sendQueue: make(chan func(), 8),
-		done:      make(chan struct{}),
-		Timeout:   10 * time.Second,
+		host:        host,
+		conn:        conn,
+		seqNums:     make(chan byte, maxSeqNum),
+		nextSeqNum:  1,
+		handlers:    make(map[handlerID][]registeredHandler),
+		sendQueue:   make(chan func(), 8),
+		stateSignal: make(chan struct{}, 1),
+		done:        make(chan struct{}),
+		timeout:     int64(10 * time.Second),
+		logger:      nopLogger{},
+	}
+
+	// All sequence numbers are free
+	for i := byte(1); i <= maxSeqNum; i++ {
+		tf.seqNums <- i
 	}
 
 	// Start the go routines
-	tf.wait.Add(3)
-	go tf.seqNumGenerator() // Sequence number generator
-	go tf.sender()          // Sender (queue for functions to send packets)
-	go tf.receiver()        // Receiver
+	tf.wait.Add(3 + callbackWorkers)
+	go tf.sender()      // Sender (queue for functions to send packets)
+	go tf.stateWorker() // Calls the connection state handler
+
+	// Callback workers (before the receiver starts to use them)
+	tf.callbacks = make([]chan *Packet, callbackWorkers)
+	for i := range tf.callbacks {
+		tf.callbacks[i] = make(chan *Packet, callbackQueue)
+		go tf.callbackWorker(tf.callbacks[i])
+	}
 
-	// Okay
-	return tf, nil
+	go tf.receiver() // Receiver
+
+	return tf
+}
+
+// dial connects to the tinkerforge service at host
+func dial(host string) (io.ReadWriteCloser, error) {
+	// Resolve service address
+	addr, err := net.ResolveTCPAddr("tcp", host)
+	if err != nil {
+		return nil, err
+	}
+
+	// Connect to service
+	return net.DialTCP("tcp", nil, addr)
 }
 
 // Close closes the connection to the tinkerforge service
 func (t *tinkerforge) Close() error {
-	// Close the channels
-	close(t.done)
-	close(t.sendQueue)
+	// Signal all go routines to stop, Close may be called more than once
+	alreadyClosed := true
+	t.closeOnce.Do(func() {
+		close(t.done)
+		alreadyClosed = false
+	})
+	if alreadyClosed {
+		return ErrClosed
+	}
 
 	// Close the tcp connection
-	if err := t.conn.Close(); err != nil {
+	if err := t.connection().Close(); err != nil {
 		return err
 	}
 
@@ -129,63 +247,432 @@ func (t *tinkerforge) Close() error {
 	return nil
 }
 
-// Send sends a new packet to the service and returns the answer (if an answer is expected)
+// SetTimeout sets how long Send waits for a response (default 10s). A value of 0 waits forever.
+// Use SendContext with a deadline to override the timeout for a single call.
+func (t *tinkerforge) SetTimeout(d time.Duration) {
+	atomic.StoreInt64(&t.timeout, int64(d))
+}
+
+// SetDropCallbacks sets whether callbacks arriving while the queue of their worker is full
+// are dropped instead of waiting for the handlers. Dropping keeps responses flowing when
+// handlers are slow, the dropped callbacks are counted in Stats. It is disabled by default.
+func (t *tinkerforge) SetDropCallbacks(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&t.dropCallbacks, v)
+}
+
+// Timeout returns how long Send waits for a response.
+func (t *tinkerforge) Timeout() time.Duration {
+	return time.Duration(atomic.LoadInt64(&t.timeout))
+}
+
+// Send sends a new packet to the service and returns the answer (if an answer is expected).
+// If the answer carries an error code, the matching error is returned along with the answer.
 func (t *tinkerforge) Send(p *Packet) (*Packet, error) {
-	var packets chan *Packet
+	return t.SendContext(context.Background(), p)
+}
+
+// SendContext sends a new packet to the service and returns the answer (if an answer is expected).
+// If ctx is done before the answer arrives, the wait is aborted and ctx.Err() is returned.
+// If ctx has a deadline, it replaces the timeout set with SetTimeout.
+func (t *tinkerforge) SendContext(ctx context.Context, p *Packet) (*Packet, error) {
+	r, err := t.send(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.wait(ctx)
+}
+
+// pending is a sent packet whose answer wasn't collected yet
+type pending struct {
+	t       *tinkerforge
+	p       *Packet
+	seqNum  byte
+	packets chan *Packet
+	lost    chan struct{}
+	sent    time.Time
+}
+
+// send writes p to the connection and registers the handler for its answer
+func (t *tinkerforge) send(ctx context.Context, p *Packet) (*pending, error) {
+	r := &pending{t: t, p: p}
 
 	errors := make(chan error, 1)
 
-	// Create response channel in case we need it
 	if p.ResponseExpected() {
-		packets = make(chan *Packet, 1)
+		// Wait until a sequence number is free, at most 15 responses can be pending
+		select {
+		case r.seqNum = <-t.seqNums:
+		case <-t.done:
+			return nil, ErrClosed
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		// Register callback for expected response
+		r.packets = make(chan *Packet, 1)
+		r.lost = make(chan struct{})
+		t.handler(p.UID(), p.FunctionID(), r.seqNum, respHandler{c: r.packets, t: t.Timeout(), lost: r.lost})
 	}
 
 	f := func() {
-		// Generate sequence number
-		seqNum := <-t.seqNum
-
-		// Register callback for expected response (if any)
-		if p.ResponseExpected() {
-			t.handler(p.UID(), p.FunctionID(), seqNum, respHandler{c: packets, t: t.Timeout})
+		num := r.seqNum
+		if !p.ResponseExpected() {
+			// No response will be routed, any sequence number will do
+			num = t.nextSeqNum
+			t.nextSeqNum = t.nextSeqNum%maxSeqNum + 1
 		}
 
 		// Send packet
-		if err := p.Serialize(t.conn, seqNum); err != nil {
+		if err := p.Serialize(t.connection(), num); err != nil {
+			if p.ResponseExpected() {
+				t.removeResponseHandler(p.UID(), p.FunctionID(), r.seqNum)
+			}
 			errors <- err
 			return
 		}
+		atomic.AddUint64(&t.stats.sent, 1)
 
 		// Close the error channel
 		close(errors)
 	}
 
 	// Dispatch f
-	t.sendQueue <- f
+	select {
+	case t.sendQueue <- f:
+	case <-t.done:
+		return nil, ErrClosed
+	case <-ctx.Done():
+		if p.ResponseExpected() {
+			t.removeResponseHandler(p.UID(), p.FunctionID(), r.seqNum)
+		}
+		return nil, ctx.Err()
+	}
 
-	// An error occurred
-	if err := <-errors; err != nil {
-		return nil, err
+	// Wait for f to be executed
+	select {
+	case err := <-errors:
+		// An error occurred
+		if err != nil {
+			return nil, err
+		}
+	case <-t.done:
+		return nil, ErrClosed
 	}
 
+	r.sent = time.Now()
+	return r, nil
+}
+
+// wait waits for the answer to the packet, a deadline of ctx replaces the timeout counted from sending
+func (r *pending) wait(ctx context.Context) (*Packet, error) {
 	// Return depending of the expected response
-	if p.ResponseExpected() {
-		result, ok := <-packets
-		if ok {
-			return result, nil
+	if !r.p.ResponseExpected() {
+		return nil, nil
+	}
+
+	t := r.t
+
+	// An answer that already arrived wins over an expired timeout
+	select {
+	case result, ok := <-r.packets:
+		return r.result(result, ok)
+	default:
+	}
+
+	// Don't wait forever for a response that never arrives
+	var timeout <-chan time.Time
+	if _, ok := ctx.Deadline(); !ok {
+		if d := t.Timeout(); d > 0 {
+			timer := time.NewTimer(time.Until(r.sent.Add(d)))
+			defer timer.Stop()
+			timeout = timer.C
 		}
-		// Timeout
+	}
+
+	select {
+	case result, ok := <-r.packets:
+		return r.result(result, ok)
+
+	case <-timeout:
+		// Timeout, remove the handler so it doesn't leak
+		atomic.AddUint64(&t.stats.timeouts, 1)
+		t.removeResponseHandler(r.p.UID(), r.p.FunctionID(), r.seqNum)
+		return nil, ErrTimeout
+
+	case <-ctx.Done():
+		// Nobody is waiting for the response anymore
+		t.removeResponseHandler(r.p.UID(), r.p.FunctionID(), r.seqNum)
+		return nil, ctx.Err()
+
+	case <-r.lost:
+		// The handler was dropped after the connection was lost
+		return nil, ErrConnectionLost
+
+	case <-t.done:
+		return nil, ErrClosed
+	}
+}
+
+// result reports the answer received by the response handler
+func (r *pending) result(p *Packet, ok bool) (*Packet, error) {
+	if !ok {
+		// The response handler timed out
+		atomic.AddUint64(&r.t.stats.timeouts, 1)
+		r.t.removeResponseHandler(r.p.UID(), r.p.FunctionID(), r.seqNum)
 		return nil, ErrTimeout
 	}
 
-	return nil, nil
+	// Report the error code of the brick(let), the packet is returned anyway
+	return p, p.Error()
 }
 
-// Handler registers a new handler for a packet
-func (t *tinkerforge) Handler(uid uint32, funcID uint8, h Handler) {
-	t.handler(uid, funcID, 0, h)
+// Broadcast sends a packet to all devices (UID 0) and returns without waiting for responses.
+// Broadcasts can't return data, the UID and response expected flag of 'p' are ignored.
+func (t *tinkerforge) Broadcast(p *Packet) error {
+	b := p.Clone()
+	b.uid = 0
+	b.respExp = false
+
+	_, err := t.Send(b)
+	return err
+}
+
+// SendRetry sends a packet like Send but retries up to 'attempts' times if the response
+// timed out, waiting 'backoff' between the attempts. Only packets expecting a response are
+// retried, so this should only be used for idempotent requests. The last error is returned.
+func (t *tinkerforge) SendRetry(p *Packet, attempts int, backoff time.Duration) (*Packet, error) {
+	// Without a response we can't detect a lost packet
+	if !p.ResponseExpected() {
+		return t.Send(p)
+	}
+
+	// Send at least once
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var (
+		result *Packet
+		err    error
+	)
+
+	for i := 0; i < attempts; i++ {
+		// Wait before retrying
+		if i > 0 {
+			select {
+			case <-time.After(backoff):
+			case <-t.done:
+				return nil, ErrClosed
+			}
+		}
+
+		// Every attempt gets its own copy of the packet
+		result, err = t.Send(p.Clone())
+		if err != ErrTimeout {
+			return result, err
+		}
+	}
+
+	return result, err
+}
+
+// SetReconnect enables or disables the automatic reconnection to the tinkerforge service.
+// After the connection is lost, a reconnect is tried every 'backoff' until it succeeds.
+// Handlers registered with Handler are kept, requests waiting for a response fail with ErrConnectionLost.
+func (t *tinkerforge) SetReconnect(enabled bool, backoff time.Duration) {
+	t.connMutex.Lock()
+	defer t.connMutex.Unlock()
+
+	t.reconnect = enabled
+	t.backoff = backoff
+}
+
+// SetConnectionStateHandler registers a function to be called with false when the
+// connection to the service is lost and with true when it was re-established.
+// The handler runs on its own go routine (in order of the changes), so it may use the client.
+func (t *tinkerforge) SetConnectionStateHandler(handler func(connected bool)) {
+	t.connMutex.Lock()
+	defer t.connMutex.Unlock()
+
+	t.stateFunc = handler
+}
+
+// connectionState queues a connection state change for the state worker, it never blocks
+func (t *tinkerforge) connectionState(connected bool) {
+	t.stateMutex.Lock()
+	t.stateQueue = append(t.stateQueue, connected)
+	t.stateMutex.Unlock()
+
+	select {
+	case t.stateSignal <- struct{}{}:
+	default:
+		// The worker was already signaled
+	}
 }
 
-// handler registers any handler (internal)
+// stateWorker calls the connection state handler (if any) for the queued state changes
+func (t *tinkerforge) stateWorker() {
+	defer t.wait.Done()
+
+	for {
+		select {
+		case <-t.stateSignal:
+		case <-t.done:
+			return
+		}
+
+		t.stateMutex.Lock()
+		states := t.stateQueue
+		t.stateQueue = nil
+		t.stateMutex.Unlock()
+
+		for _, connected := range states {
+			// A new connection has to be authenticated again
+			if connected {
+				t.reauthenticate()
+			}
+
+			t.connMutex.RLock()
+			f := t.stateFunc
+			t.connMutex.RUnlock()
+
+			if f != nil {
+				f(connected)
+			}
+		}
+	}
+}
+
+// connection returns the current connection to the service
+func (t *tinkerforge) connection() io.ReadWriteCloser {
+	t.connMutex.RLock()
+	defer t.connMutex.RUnlock()
+
+	return t.conn
+}
+
+// reconnectEnabled returns the reconnect settings
+func (t *tinkerforge) reconnectEnabled() (bool, time.Duration) {
+	t.connMutex.RLock()
+	defer t.connMutex.RUnlock()
+
+	return t.reconnect, t.backoff
+}
+
+// redial reconnects to the service until it succeeds or the client is closed
+func (t *tinkerforge) redial(backoff time.Duration) bool {
+	for {
+		// Wait before (re)trying
+		select {
+		case <-time.After(backoff):
+		case <-t.done:
+			return false
+		}
+
+		conn, err := dial(t.host)
+		if err != nil {
+			t.log().Debugf("tinkerforge: reconnect failed: %v", err)
+			continue
+		}
+
+		t.connMutex.Lock()
+
+		// Close was called while we were dialing
+		select {
+		case <-t.done:
+			t.connMutex.Unlock()
+			conn.Close()
+			return false
+		default:
+		}
+
+		t.conn.Close()
+		t.conn = conn
+		t.connMutex.Unlock()
+
+		// Responses to requests sent on the old connection will never arrive
+		t.dropResponseHandlers()
+		return true
+	}
+}
+
+// dropResponseHandlers removes all handlers waiting for a response (keeps callback handlers)
+func (t *tinkerforge) dropResponseHandlers() {
+	t.handlersMutex.Lock()
+	defer t.handlersMutex.Unlock()
+
+	for id, handlers := range t.handlers {
+		if id.seqNum != 0 {
+			// Wake up the caller waiting for the response
+			for _, r := range handlers {
+				if rh, ok := r.handler.(respHandler); ok {
+					close(rh.lost)
+				}
+			}
+
+			delete(t.handlers, id)
+			t.seqNums <- id.seqNum
+		}
+	}
+}
+
+// Handler registers a new handler for a packet, any number of handlers can be registered for the same packet.
+// The returned token removes this handler with RemoveHandler. Passing nil removes all handlers of the packet.
+// Handlers registered for UID 0 match packets of any device, see HandlerAny.
+func (t *tinkerforge) Handler(uid uint32, funcID uint8, h Handler) HandlerToken {
+	// Remove all handlers
+	if h == nil {
+		t.handler(uid, funcID, 0, nil)
+		return HandlerToken{}
+	}
+
+	t.handlersMutex.Lock()
+	defer t.handlersMutex.Unlock()
+
+	// Add handler
+	t.nextHandlerID++
+	token := HandlerToken{
+		key: handlerIDFromParam(uid, funcID, 0),
+		id:  t.nextHandlerID,
+	}
+	t.handlers[token.key] = append(t.handlers[token.key], registeredHandler{id: token.id, handler: h})
+
+	return token
+}
+
+// HandlerAny registers a handler for the callback 'funcID' of any device. It is called in
+// addition to the handlers registered for the UID of the device. Passing nil removes all
+// handlers registered with HandlerAny for 'funcID'.
+func (t *tinkerforge) HandlerAny(funcID uint8, h Handler) HandlerToken {
+	return t.Handler(0, funcID, h)
+}
+
+// RemoveHandler removes a single handler registered with Handler
+func (t *tinkerforge) RemoveHandler(token HandlerToken) {
+	t.handlersMutex.Lock()
+	defer t.handlersMutex.Unlock()
+
+	// Build a new slice, handle might still use the old one
+	var handlers []registeredHandler
+	for _, r := range t.handlers[token.key] {
+		if r.id != token.id {
+			handlers = append(handlers, r)
+		}
+	}
+
+	if len(handlers) == 0 {
+		delete(t.handlers, token.key)
+	} else {
+		t.handlers[token.key] = handlers
+	}
+}
+
+// handler replaces all handlers for a packet (internal)
 func (t *tinkerforge) handler(uid uint32, funcID, seqNum uint8, h Handler) {
 	t.handlersMutex.Lock()
 	defer t.handlersMutex.Unlock()
@@ -197,34 +684,45 @@ func (t *tinkerforge) handler(uid uint32, funcID, seqNum uint8, h Handler) {
 	}
 
 	// Add handler
-	t.handlers[handlerIDFromParam(uid, funcID, seqNum)] = h
+	t.handlers[handlerIDFromParam(uid, funcID, seqNum)] = []registeredHandler{{handler: h}}
 }
 
-// Sequence number generator
-func (t *tinkerforge) seqNumGenerator() {
+// removeResponseHandler removes the handler waiting for a response and frees its sequence number
+func (t *tinkerforge) removeResponseHandler(uid uint32, funcID, seqNum uint8) {
+	t.handlersMutex.Lock()
+	defer t.handlersMutex.Unlock()
+
+	// The handler may have been removed already (e.g. the response arrived after a timeout)
+	id := handlerIDFromParam(uid, funcID, seqNum)
+	if _, ok := t.handlers[id]; !ok {
+		return
+	}
+
+	delete(t.handlers, id)
+	t.seqNums <- seqNum
+}
+
+// Sender executes the funktions in sendQueue
+func (t *tinkerforge) sender() {
 	defer t.wait.Done()
 
-	num := byte(1)
+	// Execute all functions until the client is closed
 	for {
 		select {
-		case t.seqNum <- num:
+		case f := <-t.sendQueue:
+			f()
 		case <-t.done:
 			return
 		}
-		num++
-		if num == 16 {
-			num = 1
-		}
 	}
 }
 
-// Sender executes the funktions in sendQueue
-func (t *tinkerforge) sender() {
+// callbackWorker executes the handlers for the callbacks in c
+func (t *tinkerforge) callbackWorker(c chan *Packet) {
 	defer t.wait.Done()
 
-	// Execute all functions
-	for f := range t.sendQueue {
-		f()
+	for p := range c {
+		t.handle(p)
 	}
 }
 
@@ -232,43 +730,113 @@ func (t *tinkerforge) sender() {
 func (t *tinkerforge) receiver() {
 	defer t.wait.Done()
 
-	// Set up scanner
-	scanner := bufio.NewScanner(t.conn)
-	scanner.Split(scanPacket)
+	// Stop the callback workers when we're done
+	defer func() {
+		for _, c := range t.callbacks {
+			close(c)
+		}
+	}()
+
+	for {
+		err := t.receive(t.connection())
+
+		// The connection was closed on purpose
+		select {
+		case <-t.done:
+			return
+		default:
+		}
+
+		t.log().Errorf("tinkerforge: connection lost: %v", err)
+
+		// Responses can't arrive anymore, don't let the callers wait for them
+		t.dropResponseHandlers()
+		t.connectionState(false)
+
+		// Reconnect if enabled (only possible if we know the host)
+		enabled, backoff := t.reconnectEnabled()
+		if !enabled || t.host == "" || !t.redial(backoff) {
+			return
+		}
+
+		t.log().Debugf("tinkerforge: reconnected to %s", t.host)
+		t.connectionState(true)
+	}
+}
+
+// receive reads packets from conn until the connection fails
+func (t *tinkerforge) receive(conn io.Reader) error {
+	// Packets are read into a reusable buffer, only the packet itself is allocated
+	rd := bufio.NewReader(conn)
+	var buf [maxPacketLength]byte
+
+	for {
+		data, err := readFrame(rd, &buf)
+		if err != nil {
+			return err
+		}
 
-	// Scan for packets
-	for scanner.Scan() {
-		// Parse the packet
-		p, err := readPacket(scanner.Bytes())
+		// Parse the packet, drop it if it is malformed
+		p, err := readPacket(data)
 		if err != nil {
-			fmt.Println(err)
+			t.log().Errorf("tinkerforge: dropping malformed packet: %v", err)
 			continue
 		}
+		atomic.AddUint64(&t.stats.received, 1)
 
-		// Call the handler
+		// Callbacks are handed to a worker
+		if p.Callback() {
+			t.dispatch(p)
+			continue
+		}
+
+		// Call the response handler and remove it
 		t.handle(p)
+		t.removeResponseHandler(p.UID(), p.FunctionID(), p.SequenceNum())
+	}
+}
+
+// dispatch hands the callback p to its worker, the same uid and function ID always go to the same one.
+// If the queue of the worker is full, it waits until there is room or drops p if enabled.
+func (t *tinkerforge) dispatch(p *Packet) {
+	c := t.callbacks[(p.UID()+uint32(p.FunctionID()))%callbackWorkers]
 
-		// Remove handler if it was not a callback
-		if !p.Callback() {
-			t.handler(p.UID(), p.FunctionID(), p.SequenceNum(), nil)
+	if atomic.LoadInt32(&t.dropCallbacks) != 0 {
+		select {
+		case c <- p:
+			atomic.AddUint64(&t.stats.callbacks, 1)
+		default:
+			atomic.AddUint64(&t.stats.dropped, 1)
+			t.log().Errorf("tinkerforge: callback queue full, dropping %v", p)
 		}
+		return
+	}
+
+	select {
+	case c <- p:
+		atomic.AddUint64(&t.stats.callbacks, 1)
+	case <-t.done:
 	}
 }
 
-// handle searches for a matching hander for p and executes it
+// handle searches for the matching handers for p and executes them
 func (t *tinkerforge) handle(p *Packet) {
 	t.handlersMutex.RLock()
 
-	var handler Handler
-	handler, ok := t.handlers[handlerIDFromPacket(p)]
-	if !ok {
-		// Maybe a wildcard?
-		handler = t.handlers[handlerIDFromParam(0, p.FunctionID(), p.SequenceNum())]
+	handlers := t.handlers[handlerIDFromPacket(p)]
+
+	// Wildcards registered for UID 0 are called in addition
+	var wildcards []registeredHandler
+	if p.UID() != 0 {
+		wildcards = t.handlers[handlerIDFromParam(0, p.FunctionID(), p.SequenceNum())]
 	}
 
 	t.handlersMutex.RUnlock()
-	if handler != nil {
-		handler.Handle(p)
+	for _, r := range handlers {
+		r.handler.Handle(p)
+	}
+	for _, r := range wildcards {
+		r.handler.Handle(p)
 	}
 }
 
@@ -0,0 +1,693 @@
+package tinkerforge
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+)
+
+// newTestClient creates a client connected to the returned fake daemon connection
+func newTestClient() (*tinkerforge, net.Conn) {
+	client, daemon := net.Pipe()
+	return NewWithConn(client).(*tinkerforge), daemon
+}
+
+// respond reads one request from the daemon connection and answers it after 'delay'.
+// The returned channel receives the result once the response was written.
+func respond(daemon net.Conn, delay time.Duration, params ...interface{}) <-chan error {
+	errc := make(chan error, 1)
+
+	go func() {
+		req, err := ReadPacket(daemon)
+		if err != nil {
+			errc <- err
+			return
+		}
+
+		time.Sleep(delay)
+
+		resp, err := NewPacket(req.UID(), req.FunctionID(), false, params...)
+		if err != nil {
+			errc <- err
+			return
+		}
+
+		errc <- resp.Serialize(daemon, req.SequenceNum())
+	}()
+
+	return errc
+}
+
+func TestSendTimeout(t *testing.T) {
+	tf, daemon := newTestClient()
+	defer daemon.Close()
+	defer tf.Close()
+	tf.SetTimeout(20 * time.Millisecond)
+
+	errc := respond(daemon, 100*time.Millisecond, uint16(42))
+	defer func() {
+		if err := <-errc; err != nil {
+			t.Error(err)
+		}
+	}()
+
+	p, _ := NewPacket(1, 2, true)
+	if _, err := tf.Send(p); err != ErrTimeout {
+		t.Errorf("Send() error = %v, want ErrTimeout", err)
+	}
+}
+
+func TestSendContextDeadlineReplacesTimeout(t *testing.T) {
+	tf, daemon := newTestClient()
+	defer daemon.Close()
+	defer tf.Close()
+	tf.SetTimeout(20 * time.Millisecond)
+
+	errc := respond(daemon, 100*time.Millisecond, uint16(42))
+	defer func() {
+		if err := <-errc; err != nil {
+			t.Error(err)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	p, _ := NewPacket(1, 2, true)
+	res, err := tf.SendContext(ctx, p)
+	if err != nil {
+		t.Fatalf("SendContext() error = %v", err)
+	}
+
+	var value uint16
+	if err := res.Decode(&value); err != nil || value != 42 {
+		t.Errorf("Decode() = %d, %v, want 42", value, err)
+	}
+}
+
+// discard reads and drops all requests until the connection is closed
+func discard(daemon net.Conn) {
+	for {
+		if _, err := ReadPacket(daemon); err != nil {
+			return
+		}
+	}
+}
+
+func TestSendContextCanceledWaitingForSequenceNumber(t *testing.T) {
+	tf, daemon := newTestClient()
+	defer daemon.Close()
+	defer tf.Close()
+	tf.SetTimeout(0)
+
+	go discard(daemon)
+
+	// Use up all sequence numbers with requests that are never answered
+	for i := 0; i < maxSeqNum; i++ {
+		go func() {
+			p, _ := NewPacket(1, 2, true)
+			tf.Send(p)
+		}()
+	}
+	for len(tf.seqNums) > 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Packets without response don't need a sequence number
+	p, _ := NewPacket(1, 3, false)
+	if _, err := tf.Send(p); err != nil {
+		t.Errorf("Send() without response error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	p, _ = NewPacket(1, 2, true)
+	if _, err := tf.SendContext(ctx, p); err != context.DeadlineExceeded {
+		t.Errorf("SendContext() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestSendWhileClosing(t *testing.T) {
+	tf, daemon := newTestClient()
+	defer daemon.Close()
+	go discard(daemon)
+
+	// Send from several go routines until the client is closed
+	errc := make(chan error)
+	for i := 0; i < 8; i++ {
+		go func(i int) {
+			for {
+				p, _ := NewPacket(1, 2, i%2 == 0)
+
+				var err error
+				if i%4 < 2 {
+					_, err = tf.Send(p)
+				} else {
+					ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+					_, err = tf.SendContext(ctx, p)
+					cancel()
+				}
+
+				if err != nil {
+					errc <- err
+					return
+				}
+			}
+		}(i)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if err := tf.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 8; i++ {
+		select {
+		case err := <-errc:
+			if err != ErrClosed {
+				t.Errorf("Send() error = %v, want ErrClosed", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Send() did not return after Close")
+		}
+	}
+
+	// Using the closed client fails
+	p, _ := NewPacket(1, 2, true)
+	if _, err := tf.Send(p); err != ErrClosed {
+		t.Errorf("Send() after Close error = %v, want ErrClosed", err)
+	}
+	if err := tf.Close(); err != ErrClosed {
+		t.Errorf("second Close() error = %v, want ErrClosed", err)
+	}
+}
+
+func TestSendConnectionLost(t *testing.T) {
+	tf, daemon := newTestClient()
+	defer tf.Close()
+	tf.SetTimeout(0)
+
+	// Drop the connection as soon as the request arrives
+	go func() {
+		ReadPacket(daemon)
+		daemon.Close()
+	}()
+
+	errc := make(chan error, 1)
+	go func() {
+		p, _ := NewPacket(1, 2, true)
+		_, err := tf.Send(p)
+		errc <- err
+	}()
+
+	select {
+	case err := <-errc:
+		if err != ErrConnectionLost {
+			t.Errorf("Send() error = %v, want ErrConnectionLost", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("Send() still waiting after the connection was lost")
+	}
+
+	// The sequence number was released
+	if len(tf.seqNums) != maxSeqNum {
+		t.Errorf("%d free sequence numbers, want %d", len(tf.seqNums), maxSeqNum)
+	}
+}
+
+// writeResponse answers req with the error code and the raw payload
+func writeResponse(daemon net.Conn, req *Packet, errorCode ErrorCode, payload ...byte) error {
+	header := []byte{0, 0, 0, 0, byte(8 + len(payload)), req.FunctionID(), req.SequenceNum() << 4, byte(errorCode) << 6}
+	binary.LittleEndian.PutUint32(header, req.UID())
+	_, err := daemon.Write(append(header, payload...))
+	return err
+}
+
+func TestSendErrorCode(t *testing.T) {
+	tf, daemon := newTestClient()
+	defer daemon.Close()
+	defer tf.Close()
+	tf.SetTimeout(time.Second)
+
+	// The daemon answers with ECInvalidParam and a payload
+	errc := make(chan error, 1)
+	go func() {
+		req, err := ReadPacket(daemon)
+		if err != nil {
+			errc <- err
+			return
+		}
+
+		errc <- writeResponse(daemon, req, ECInvalidParam, 0x2a, 0x00)
+	}()
+
+	p, _ := NewPacket(1, 2, true)
+	res, err := tf.Send(p)
+	if err != ErrInvalidParam {
+		t.Errorf("Send() error = %v, want ErrInvalidParam", err)
+	}
+	if err := <-errc; err != nil {
+		t.Fatal(err)
+	}
+
+	// The raw packet is returned with the error
+	if res == nil {
+		t.Fatal("Send() returned no packet with the error")
+	}
+	if res.ErrorID() != ECInvalidParam || res.FunctionID() != 2 || !bytes.Equal(res.Payload(), []byte{0x2a, 0x00}) {
+		t.Errorf("Send() = %v, want the response with ECInvalidParam", res)
+	}
+}
+
+func TestConnectionStateHandlerCanSend(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	// The daemon drops the first connection and answers all requests on the second
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		time.Sleep(50 * time.Millisecond) // give the client time to enable reconnecting
+		conn.Close()
+
+		conn, err = l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for {
+			req, err := ReadPacket(conn)
+			if err != nil {
+				return
+			}
+			resp, _ := NewPacket(req.UID(), req.FunctionID(), false, uint16(42))
+			resp.Serialize(conn, req.SequenceNum())
+		}
+	}()
+
+	tf, err := New(l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tf.Close()
+	tf.SetTimeout(time.Second)
+
+	errc := make(chan error, 1)
+	tf.SetConnectionStateHandler(func(connected bool) {
+		if !connected {
+			return
+		}
+
+		p, _ := NewPacket(1, 2, true)
+		_, err := tf.Send(p)
+		errc <- err
+	})
+	tf.SetReconnect(true, 10*time.Millisecond)
+
+	select {
+	case err := <-errc:
+		if err != nil {
+			t.Errorf("Send() from the state handler error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("state handler was not called after reconnecting")
+	}
+}
+
+// expectCalls checks that each channel received exactly one call
+func expectCalls(t *testing.T, fired []chan struct{}, want []bool) {
+	t.Helper()
+
+	for i, c := range fired {
+		select {
+		case <-c:
+			if !want[i] {
+				t.Errorf("handler %d was called", i)
+			}
+		case <-time.After(50 * time.Millisecond):
+			if want[i] {
+				t.Errorf("handler %d was not called", i)
+			}
+		}
+	}
+}
+
+func TestHandlerFanOut(t *testing.T) {
+	tf, daemon := newTestClient()
+	defer daemon.Close()
+	defer tf.Close()
+
+	fired := []chan struct{}{make(chan struct{}, 1), make(chan struct{}, 1), make(chan struct{}, 1)}
+	var tokens []HandlerToken
+	for _, c := range fired {
+		c := c
+		tokens = append(tokens, tf.Handler(1, 10, enumerateFunc(func(p *Packet) { c <- struct{}{} })))
+	}
+
+	fire := func() {
+		p, _ := NewPacket(1, 10, false)
+		if err := p.Serialize(daemon, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// All handlers of the packet are called
+	fire()
+	expectCalls(t, fired, []bool{true, true, true})
+
+	// RemoveHandler removes only the handler of the token
+	tf.RemoveHandler(tokens[1])
+	fire()
+	expectCalls(t, fired, []bool{true, false, true})
+
+	// Passing nil removes all remaining handlers
+	if token := tf.Handler(1, 10, nil); token != (HandlerToken{}) {
+		t.Errorf("Handler(nil) = %+v, want the zero token", token)
+	}
+	fire()
+	expectCalls(t, fired, []bool{false, false, false})
+}
+
+// requests reads the requests from the daemon connection into the returned channel
+func requests(daemon net.Conn) <-chan *Packet {
+	c := make(chan *Packet, maxSeqNum)
+
+	go func() {
+		defer close(c)
+		for {
+			req, err := ReadPacket(daemon)
+			if err != nil {
+				return
+			}
+			c <- req
+		}
+	}()
+
+	return c
+}
+
+// nextRequest returns the next request or fails the test after a second
+func nextRequest(t *testing.T, reqs <-chan *Packet) *Packet {
+	t.Helper()
+
+	select {
+	case req := <-reqs:
+		return req
+	case <-time.After(time.Second):
+		t.Fatal("no request was sent")
+		return nil
+	}
+}
+
+// noRequest fails the test if another request arrives
+func noRequest(t *testing.T, reqs <-chan *Packet) {
+	t.Helper()
+
+	select {
+	case req := <-reqs:
+		t.Errorf("unexpected request %v", req)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestSendRetryTimeout(t *testing.T) {
+	tf, daemon := newTestClient()
+	defer daemon.Close()
+	defer tf.Close()
+	tf.SetTimeout(20 * time.Millisecond)
+
+	// The first two attempts are lost, the third one is answered
+	reqs := requests(daemon)
+	errc := make(chan error, 1)
+	go func() {
+		<-reqs
+		<-reqs
+		errc <- writeResponse(daemon, <-reqs, ECOkay, 0x2a, 0x00)
+	}()
+
+	p, _ := NewPacket(1, 2, true)
+	res, err := tf.SendRetry(p, 3, time.Millisecond)
+	if err != nil {
+		t.Fatalf("SendRetry() error = %v", err)
+	}
+	if err := <-errc; err != nil {
+		t.Fatal(err)
+	}
+
+	var value uint16
+	if err := res.Decode(&value); err != nil || value != 42 {
+		t.Errorf("SendRetry() = %v, want the answer 42", res)
+	}
+	noRequest(t, reqs)
+}
+
+func TestSendRetryOtherError(t *testing.T) {
+	tf, daemon := newTestClient()
+	defer daemon.Close()
+	defer tf.Close()
+	tf.SetTimeout(time.Second)
+
+	reqs := requests(daemon)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- writeResponse(daemon, <-reqs, ECFuncNotSupported)
+	}()
+
+	// Errors other than timeouts are not retried
+	p, _ := NewPacket(1, 2, true)
+	if _, err := tf.SendRetry(p, 3, time.Millisecond); err != ErrFuncNotSupported {
+		t.Errorf("SendRetry() error = %v, want ErrFuncNotSupported", err)
+	}
+	if err := <-errc; err != nil {
+		t.Fatal(err)
+	}
+	noRequest(t, reqs)
+}
+
+func TestSendRetryWithoutResponse(t *testing.T) {
+	tf, daemon := newTestClient()
+	defer daemon.Close()
+	defer tf.Close()
+
+	reqs := requests(daemon)
+
+	// Packets without response are sent once
+	p, _ := NewPacket(1, 2, false)
+	res, err := tf.SendRetry(p, 3, time.Millisecond)
+	if res != nil || err != nil {
+		t.Errorf("SendRetry() = %v, %v, want nil, nil", res, err)
+	}
+
+	if req := nextRequest(t, reqs); req.ResponseExpected() || req.FunctionID() != 2 {
+		t.Errorf("sent %v, want function ID 2 without response", req)
+	}
+	noRequest(t, reqs)
+}
+
+func TestSendRetryClose(t *testing.T) {
+	tf, daemon := newTestClient()
+	defer daemon.Close()
+	tf.SetTimeout(20 * time.Millisecond)
+
+	reqs := requests(daemon)
+
+	errc := make(chan error, 1)
+	go func() {
+		p, _ := NewPacket(1, 2, true)
+		_, err := tf.SendRetry(p, 3, time.Hour)
+		errc <- err
+	}()
+
+	// Close while SendRetry waits for the next attempt
+	nextRequest(t, reqs)
+	time.Sleep(50 * time.Millisecond)
+	tf.Close()
+
+	select {
+	case err := <-errc:
+		if err != ErrClosed {
+			t.Errorf("SendRetry() error = %v, want ErrClosed", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("SendRetry() did not return after Close")
+	}
+}
+
+func TestBroadcast(t *testing.T) {
+	tf, daemon := newTestClient()
+	defer daemon.Close()
+	defer tf.Close()
+
+	reqs := requests(daemon)
+
+	p, _ := NewPacket(1, 2, true, uint16(42))
+	if err := tf.Broadcast(p); err != nil {
+		t.Fatalf("Broadcast() error = %v", err)
+	}
+
+	// The packet goes to all devices without response
+	req := nextRequest(t, reqs)
+	if req.UID() != 0 || req.ResponseExpected() || req.FunctionID() != 2 || !bytes.Equal(req.Payload(), []byte{0x2a, 0x00}) {
+		t.Errorf("sent %v, want UID 0 without response", req)
+	}
+
+	// The packet of the caller is left alone
+	if p.UID() != 1 || !p.ResponseExpected() {
+		t.Errorf("Broadcast() changed the packet to %v", p)
+	}
+}
+
+func TestHandlerAny(t *testing.T) {
+	tf, daemon := newTestClient()
+	defer daemon.Close()
+	defer tf.Close()
+
+	fired := []chan struct{}{make(chan struct{}, 1), make(chan struct{}, 1)}
+	token := tf.HandlerAny(10, enumerateFunc(func(p *Packet) { fired[0] <- struct{}{} }))
+	tf.Handler(1, 10, enumerateFunc(func(p *Packet) { fired[1] <- struct{}{} }))
+
+	fire := func(uid uint32) {
+		p, _ := NewPacket(uid, 10, false)
+		if err := p.Serialize(daemon, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The handler for any device is called alongside the handler of the device
+	fire(1)
+	expectCalls(t, fired, []bool{true, true})
+
+	// Other devices only reach the handler for any device
+	fire(2)
+	expectCalls(t, fired, []bool{true, false})
+
+	// Removing the handler for any device keeps the handler of the device
+	tf.RemoveHandler(token)
+	fire(1)
+	expectCalls(t, fired, []bool{false, true})
+}
+
+type blockingHandler chan struct{}
+
+func (b blockingHandler) Handle(p *Packet) { <-b }
+
+func TestCallbackBackpressure(t *testing.T) {
+	tf, daemon := newTestClient()
+	defer daemon.Close()
+	defer tf.Close()
+
+	release := make(chan struct{})
+	n := 2 * callbackQueue
+	calls := make(chan struct{}, n)
+	tf.Handler(1, 10, enumerateFunc(func(p *Packet) {
+		<-release
+		calls <- struct{}{}
+	}))
+
+	// Flood a single worker with more callbacks than it can queue
+	written := make(chan error, 1)
+	go func() {
+		callback, _ := NewPacket(1, 10, false)
+		for i := 0; i < n; i++ {
+			if err := callback.Serialize(daemon, 0); err != nil {
+				written <- err
+				return
+			}
+		}
+		written <- nil
+	}()
+
+	// The receiver waits for the handler instead of reading on
+	select {
+	case err := <-written:
+		t.Fatalf("all callbacks were written while the handler was blocked (%v)", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	if err := <-written; err != nil {
+		t.Fatal(err)
+	}
+
+	// No callback was lost
+	for i := 0; i < n; i++ {
+		select {
+		case <-calls:
+		case <-time.After(time.Second):
+			t.Fatalf("handler was called %d times, want %d", i, n)
+		}
+	}
+
+	if stats := tf.Stats(); stats.Dropped != 0 {
+		t.Errorf("Stats() = %+v, want no dropped callbacks", stats)
+	}
+}
+
+func TestDropCallbacksDoesNotBlockReceiver(t *testing.T) {
+	tf, daemon := newTestClient()
+	defer daemon.Close()
+	defer tf.Close()
+	tf.SetTimeout(time.Second)
+	tf.SetDropCallbacks(true)
+
+	block := make(blockingHandler)
+	defer close(block)
+	tf.Handler(1, 10, block)
+
+	// Flood a single worker with more callbacks than it can queue
+	callback, _ := NewPacket(1, 10, false)
+	for i := 0; i < 2*callbackQueue; i++ {
+		if err := callback.Serialize(daemon, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Responses still arrive
+	errc := respond(daemon, 0, uint16(42))
+	p, _ := NewPacket(1, 2, true)
+	if _, err := tf.Send(p); err != nil {
+		t.Errorf("Send() error = %v", err)
+	}
+	if err := <-errc; err != nil {
+		t.Error(err)
+	}
+
+	if stats := tf.Stats(); stats.Dropped == 0 {
+		t.Errorf("Stats() = %+v, want dropped callbacks", stats)
+	}
+}
+
+func BenchmarkReceive(b *testing.B) {
+	// A flood of 1000 callbacks nobody listens to
+	var stream bytes.Buffer
+	p, err := NewPacket(1, 10, false, uint32(70000), true)
+	if err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < 1000; i++ {
+		if err := p.Serialize(&stream, 0); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	t, daemon := newTestClient()
+	defer daemon.Close()
+	defer t.Close()
+
+	b.SetBytes(int64(stream.Len()))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		t.receive(bytes.NewReader(stream.Bytes()))
+	}
+}
@@ -0,0 +1,43 @@
+package tinkerforgetest
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+// Expectation describes the packet a method of a device is expected to send.
+type Expectation struct {
+	Name    string       // name of the method, used in failure messages
+	Call    func() error // calls the method
+	FuncID  uint8
+	Payload []byte
+}
+
+// Expect runs the calls of 'expectations' in order and checks that each of them sent
+// exactly one packet to the device 'uid' with the expected function ID and payload.
+func (m *Mock) Expect(t testing.TB, uid uint32, expectations []Expectation) {
+	t.Helper()
+
+	for _, e := range expectations {
+		before := len(m.Sent())
+
+		if err := e.Call(); err != nil {
+			t.Fatalf("%s: %v", e.Name, err)
+		}
+
+		sent, err := m.WaitSent(before+1, time.Second)
+		if err != nil {
+			t.Fatalf("%s: %v", e.Name, err)
+		}
+
+		if len(sent) != before+1 {
+			t.Errorf("%s: sent %d packets, want 1", e.Name, len(sent)-before)
+		}
+
+		p := sent[before]
+		if p.UID() != uid || p.FunctionID() != e.FuncID || !bytes.Equal(p.Payload(), e.Payload) {
+			t.Errorf("%s: got %v, want function ID %d and payload % x", e.Name, p, e.FuncID, e.Payload)
+		}
+	}
+}
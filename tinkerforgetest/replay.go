@@ -0,0 +1,253 @@
+package tinkerforgetest
+
+import (
+	"encoding/binary"
+	"io"
+	"sync"
+)
+
+// Directions of the recorded data
+const (
+	recordRead  byte = 'r'
+	recordWrite byte = 'w'
+)
+
+// RecordingConn wraps a connection and records all data read and written.
+// Pass it to tinkerforge.NewWithConn to capture a session for a ReplayConn.
+type RecordingConn struct {
+	conn  io.ReadWriteCloser
+	w     io.Writer
+	mutex sync.Mutex
+}
+
+// NewRecordingConn creates a new recording connection writing the recording to 'w'.
+// The caller is responsible for closing 'w' after the connection has been closed.
+func NewRecordingConn(conn io.ReadWriteCloser, w io.Writer) *RecordingConn {
+	return &RecordingConn{
+		conn: conn,
+		w:    w,
+	}
+}
+
+// Read reads from the connection and records the data
+func (r *RecordingConn) Read(b []byte) (int, error) {
+	n, err := r.conn.Read(b)
+	if n > 0 {
+		if rerr := r.record(recordRead, b[:n]); rerr != nil && err == nil {
+			err = rerr
+		}
+	}
+
+	return n, err
+}
+
+// Write records the data and writes it to the connection
+func (r *RecordingConn) Write(b []byte) (int, error) {
+	if err := r.record(recordWrite, b); err != nil {
+		return 0, err
+	}
+
+	return r.conn.Write(b)
+}
+
+// Close closes the connection
+func (r *RecordingConn) Close() error {
+	return r.conn.Close()
+}
+
+// record writes a direction byte, the length and the data to the recording
+func (r *RecordingConn) record(direction byte, data []byte) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	header := struct {
+		Direction byte
+		Len       uint32
+	}{
+		Direction: direction,
+		Len:       uint32(len(data)),
+	}
+
+	if err := binary.Write(r.w, binary.LittleEndian, &header); err != nil {
+		return err
+	}
+
+	_, err := r.w.Write(data)
+	return err
+}
+
+type record struct {
+	write bool
+	data  []byte // one packet
+}
+
+// request identifies a request by its recorded sequence number
+type request struct {
+	uid    uint32
+	funcID uint8
+	seqNum uint8
+}
+
+// ReplayConn plays back a recording made with a RecordingConn. Recorded reads are
+// only returned after the writes recorded before them happened, so responses arrive
+// after their requests. The content of the written data is discarded, except for the
+// sequence numbers: responses get the sequence number of the matching request written
+// during the replay, so the client may use other sequence numbers than in the recording.
+// The requests still have to be sent in the recorded order.
+type ReplayConn struct {
+	records []record
+	pos     int              // current record
+	off     int              // offset in the current record
+	written []byte           // data of an incomplete packet written to the connection
+	seqNums map[request]byte // sequence numbers used by the client for recorded requests
+	closed  bool
+	mutex   sync.Mutex
+	cond    *sync.Cond
+}
+
+// NewReplayConn reads a recording from 'rd' and creates a connection replaying it.
+func NewReplayConn(rd io.Reader) (*ReplayConn, error) {
+	r := &ReplayConn{seqNums: make(map[request]byte)}
+	r.cond = sync.NewCond(&r.mutex)
+
+	// Data is recorded in chunks, split it into packets per direction
+	var reads, writes []byte
+
+	for {
+		var header struct {
+			Direction byte
+			Len       uint32
+		}
+
+		if err := binary.Read(rd, binary.LittleEndian, &header); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+
+		data := make([]byte, header.Len)
+		if _, err := io.ReadFull(rd, data); err != nil {
+			return nil, err
+		}
+
+		if header.Direction == recordWrite {
+			writes = r.appendPackets(append(writes, data...), true)
+		} else {
+			reads = r.appendPackets(append(reads, data...), false)
+		}
+	}
+
+	return r, nil
+}
+
+// appendPackets adds the complete packets in 'data' to the records and returns the remaining data
+func (r *ReplayConn) appendPackets(data []byte, write bool) []byte {
+	for {
+		p := nextPacket(data)
+		if p == nil {
+			return data
+		}
+
+		r.records = append(r.records, record{write: write, data: p})
+		data = data[len(p):]
+	}
+}
+
+// nextPacket returns the first complete packet in 'data' or nil
+func nextPacket(data []byte) []byte {
+	if len(data) < 8 || len(data) < int(data[4]) || data[4] < 8 {
+		return nil
+	}
+
+	return data[:data[4]]
+}
+
+// Read returns the next recorded data read from the connection. It blocks until the
+// preceding writes happened. After the recording ends it blocks until the connection is closed.
+func (r *ReplayConn) Read(b []byte) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for !r.closed && (r.pos >= len(r.records) || r.records[r.pos].write) {
+		r.cond.Wait()
+	}
+
+	if r.closed {
+		return 0, io.EOF
+	}
+
+	// Route the response to the request the client sent (callbacks have sequence number 0)
+	data := r.records[r.pos].data
+	if r.off == 0 && data[6]>>4 != 0 {
+		if seqNum, ok := r.seqNums[requestOf(data)]; ok {
+			data[6] = data[6]&0x0f | seqNum<<4
+		}
+	}
+
+	n := copy(b, data[r.off:])
+	r.advance(n)
+
+	return n, nil
+}
+
+// Write discards the data and allows the replay to continue.
+func (r *ReplayConn) Write(b []byte) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.closed {
+		return 0, io.ErrClosedPipe
+	}
+
+	// Each packet written consumes a recorded write, additional packets are ignored
+	r.written = append(r.written, b...)
+	if len(r.written) >= 8 && r.written[4] < 8 {
+		// Not a packet, the data can't be matched to the recording
+		r.written = nil
+	}
+	for {
+		p := nextPacket(r.written)
+		if p == nil {
+			break
+		}
+
+		if r.pos < len(r.records) && r.records[r.pos].write {
+			r.seqNums[requestOf(r.records[r.pos].data)] = p[6] >> 4
+			r.pos++
+			r.off = 0
+		}
+		r.written = r.written[len(p):]
+	}
+
+	r.cond.Broadcast()
+	return len(b), nil
+}
+
+// requestOf returns the request the packet 'data' belongs to
+func requestOf(data []byte) request {
+	return request{
+		uid:    binary.LittleEndian.Uint32(data[0:4]),
+		funcID: data[5],
+		seqNum: data[6] >> 4,
+	}
+}
+
+// Close stops the replay
+func (r *ReplayConn) Close() error {
+	r.mutex.Lock()
+	r.closed = true
+	r.mutex.Unlock()
+
+	r.cond.Broadcast()
+	return nil
+}
+
+// advance moves the replay position by 'n' bytes within the current record
+func (r *ReplayConn) advance(n int) {
+	r.off += n
+	if r.off == len(r.records[r.pos].data) {
+		r.pos++
+		r.off = 0
+	}
+}
@@ -0,0 +1,111 @@
+package tinkerforgetest
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"testing"
+
+	"github.com/noxer/tinkerforge"
+)
+
+// serveDouble answers every request with its uint16 parameter doubled
+func serveDouble(conn net.Conn) {
+	for {
+		req, err := tinkerforge.ReadPacket(conn)
+		if err != nil {
+			return
+		}
+
+		var value uint16
+		req.Decode(&value)
+
+		resp, _ := tinkerforge.NewPacket(req.UID(), req.FunctionID(), false, 2*value)
+		if err := resp.Serialize(conn, req.SequenceNum()); err != nil {
+			return
+		}
+	}
+}
+
+// sendDouble sends 'value' to function 'funcID' and checks the doubled response
+func sendDouble(t *testing.T, tf tinkerforge.Tinkerforge, funcID uint8, value uint16) {
+	t.Helper()
+
+	p, _ := tinkerforge.NewPacket(30867, funcID, true, value)
+	res, err := tf.Send(p)
+	if err != nil {
+		t.Fatalf("Send(%d, %d) error = %v", funcID, value, err)
+	}
+
+	var got uint16
+	if err := res.Decode(&got); err != nil || got != 2*value {
+		t.Errorf("Send(%d, %d) = %d, %v, want %d", funcID, value, got, err, 2*value)
+	}
+}
+
+func TestRecordReplay(t *testing.T) {
+	// Record a session with a fake daemon
+	var recording bytes.Buffer
+
+	client, daemon := net.Pipe()
+	go serveDouble(daemon)
+
+	tf := tinkerforge.NewWithConn(NewRecordingConn(client, &recording))
+	for i := uint16(1); i <= 3; i++ {
+		sendDouble(t, tf, uint8(i), 10*i)
+	}
+	tf.Close()
+	daemon.Close()
+
+	// Replay it without the daemon
+	replay, err := NewReplayConn(bytes.NewReader(recording.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tf = tinkerforge.NewWithConn(replay)
+	defer tf.Close()
+
+	for i := uint16(1); i <= 3; i++ {
+		sendDouble(t, tf, uint8(i), 10*i)
+	}
+}
+
+// appendRecord appends packet 'p' with sequence number 'seqNum' to the recording
+func appendRecord(t *testing.T, recording *bytes.Buffer, direction byte, p *tinkerforge.Packet, seqNum byte) {
+	var data bytes.Buffer
+	if err := p.Serialize(&data, seqNum); err != nil {
+		t.Fatal(err)
+	}
+
+	recording.WriteByte(direction)
+	binary.Write(recording, binary.LittleEndian, uint32(data.Len()))
+	recording.Write(data.Bytes())
+}
+
+func TestReplayRewritesSequenceNumbers(t *testing.T) {
+	// The recorded client used sequence number 9, a new client starts with 1
+	var recording bytes.Buffer
+	req, _ := tinkerforge.NewPacket(30867, 2, true)
+	resp, _ := tinkerforge.NewPacket(30867, 2, false, uint16(42))
+	appendRecord(t, &recording, recordWrite, req, 9)
+	appendRecord(t, &recording, recordRead, resp, 9)
+
+	replay, err := NewReplayConn(&recording)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tf := tinkerforge.NewWithConn(replay)
+	defer tf.Close()
+
+	res, err := tf.Send(req)
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	var value uint16
+	if err := res.Decode(&value); err != nil || value != 42 {
+		t.Errorf("Decode() = %d, %v, want 42", value, err)
+	}
+}
@@ -0,0 +1,192 @@
+// Package tinkerforgetest provides a mock brick daemon for testing code using the tinkerforge package
+// Author: Tim Scheuermann (https://github.com/noxer)
+package tinkerforgetest
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/noxer/tinkerforge"
+)
+
+// Mock is a tinkerforge client connected to a fake brick daemon. Responses are
+// scripted with Respond, the packets sent by the client can be inspected with Sent.
+type Mock struct {
+	tinkerforge.Tinkerforge
+
+	conn      net.Conn
+	connMutex sync.Mutex
+
+	responses map[key]*tinkerforge.Packet
+	sent      []*tinkerforge.Packet
+	sentChan  chan struct{} // closed when a packet was sent
+	mutex     sync.Mutex
+
+	done chan struct{}
+}
+
+type key struct {
+	uid    uint32
+	funcID uint8
+}
+
+// New creates a new mock. It has to be closed after use.
+func New() *Mock {
+	client, daemon := net.Pipe()
+
+	m := &Mock{
+		Tinkerforge: tinkerforge.NewWithConn(client),
+		conn:        daemon,
+		responses:   make(map[key]*tinkerforge.Packet),
+		sentChan:    make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+
+	go m.serve()
+
+	return m
+}
+
+// Close closes the client and the fake brick daemon.
+func (m *Mock) Close() error {
+	err := m.Tinkerforge.Close()
+	m.conn.Close()
+	<-m.done
+
+	return err
+}
+
+// Respond sets the response to requests for 'funcID' of the device 'uid'.
+// The payload of the response is encoded from 'params' like in tinkerforge.NewPacket.
+// Requests without a scripted response are answered with ErrFuncNotSupported.
+func (m *Mock) Respond(uid uint32, funcID uint8, params ...interface{}) error {
+	p, err := tinkerforge.NewPacket(uid, funcID, false, params...)
+	if err != nil {
+		return err
+	}
+
+	m.mutex.Lock()
+	m.responses[key{uid: uid, funcID: funcID}] = p
+	m.mutex.Unlock()
+
+	return nil
+}
+
+// Fire sends the callback 'funcID' of the device 'uid' to the client, the payload
+// is encoded from 'params'. Callbacks are delivered to the handlers asynchronously.
+func (m *Mock) Fire(uid uint32, funcID uint8, params ...interface{}) error {
+	p, err := tinkerforge.NewPacket(uid, funcID, false, params...)
+	if err != nil {
+		return err
+	}
+
+	// Callbacks carry sequence number 0
+	return m.write(uid, funcID, 0, tinkerforge.ECOkay, p.Payload())
+}
+
+// Sent returns all packets the client sent so far.
+func (m *Mock) Sent() []*tinkerforge.Packet {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	sent := make([]*tinkerforge.Packet, len(m.sent))
+	copy(sent, m.sent)
+	return sent
+}
+
+// WaitSent waits until the client sent at least 'n' packets and returns all sent packets.
+// Packets without response are recorded shortly after Send returns, so tests should use
+// WaitSent instead of Sent for them. An error is returned if 'timeout' expires first.
+func (m *Mock) WaitSent(n int, timeout time.Duration) ([]*tinkerforge.Packet, error) {
+	deadline := time.After(timeout)
+
+	for {
+		m.mutex.Lock()
+		count, sentChan := len(m.sent), m.sentChan
+		m.mutex.Unlock()
+
+		if count >= n {
+			return m.Sent(), nil
+		}
+
+		select {
+		case <-sentChan:
+		case <-deadline:
+			return m.Sent(), fmt.Errorf("only %d of %d packets sent", count, n)
+		}
+	}
+}
+
+// Reset forgets all sent packets and scripted responses.
+func (m *Mock) Reset() {
+	m.mutex.Lock()
+	m.responses = make(map[key]*tinkerforge.Packet)
+	m.sent = nil
+	m.mutex.Unlock()
+}
+
+// serve reads the packets of the client and answers them
+func (m *Mock) serve() {
+	defer close(m.done)
+
+	for {
+		p, err := tinkerforge.ReadPacket(m.conn)
+		if err != nil {
+			return
+		}
+
+		m.mutex.Lock()
+		m.sent = append(m.sent, p)
+		close(m.sentChan)
+		m.sentChan = make(chan struct{})
+		resp, ok := m.responses[key{uid: p.UID(), funcID: p.FunctionID()}]
+		m.mutex.Unlock()
+
+		if !p.ResponseExpected() {
+			continue
+		}
+
+		if ok {
+			err = m.write(p.UID(), p.FunctionID(), p.SequenceNum(), tinkerforge.ECOkay, resp.Payload())
+		} else {
+			err = m.write(p.UID(), p.FunctionID(), p.SequenceNum(), tinkerforge.ECFuncNotSupported, nil)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// write sends a packet to the client
+func (m *Mock) write(uid uint32, funcID, seqNum uint8, errorCode tinkerforge.ErrorCode, payload []byte) error {
+	header := struct {
+		UID    uint32
+		Len    uint8
+		FuncID uint8
+		Seq    uint8
+		Flags  uint8
+	}{
+		UID:    uid,
+		Len:    uint8(8 + len(payload)),
+		FuncID: funcID,
+		Seq:    seqNum << 4,
+		Flags:  uint8(errorCode) << 6,
+	}
+
+	m.connMutex.Lock()
+	defer m.connMutex.Unlock()
+
+	if err := binary.Write(m.conn, binary.LittleEndian, &header); err != nil {
+		return err
+	}
+
+	if len(payload) == 0 {
+		return nil
+	}
+
+	_, err := m.conn.Write(payload)
+	return err
+}
@@ -0,0 +1,118 @@
+package tinkerforgetest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/noxer/tinkerforge"
+)
+
+// handlerFunc adapts a function to the tinkerforge.Handler interface
+type handlerFunc func(p *tinkerforge.Packet)
+
+func (f handlerFunc) Handle(p *tinkerforge.Packet) { f(p) }
+
+func TestRespond(t *testing.T) {
+	m := New()
+	defer m.Close()
+
+	if err := m.Respond(30867, 2, uint16(42)); err != nil {
+		t.Fatal(err)
+	}
+
+	p, _ := tinkerforge.NewPacket(30867, 2, true)
+	res, err := m.Send(p)
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	var value uint16
+	if err := res.Decode(&value); err != nil || value != 42 {
+		t.Errorf("Decode() = %d, %v, want 42", value, err)
+	}
+
+	sent := m.Sent()
+	if len(sent) != 1 || sent[0].UID() != 30867 || sent[0].FunctionID() != 2 {
+		t.Errorf("Sent() = %v, want the request", sent)
+	}
+}
+
+func TestUnscriptedRequest(t *testing.T) {
+	m := New()
+	defer m.Close()
+
+	p, _ := tinkerforge.NewPacket(30867, 3, true)
+	if _, err := m.Send(p); err != tinkerforge.ErrFuncNotSupported {
+		t.Errorf("Send() error = %v, want ErrFuncNotSupported", err)
+	}
+}
+
+func TestFire(t *testing.T) {
+	m := New()
+	defer m.Close()
+
+	got := make(chan *tinkerforge.Packet, 1)
+	m.Handler(30867, 9, handlerFunc(func(p *tinkerforge.Packet) { got <- p }))
+
+	if err := m.Fire(30867, 9, uint8(7)); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case p := <-got:
+		var value uint8
+		if !p.Callback() || p.Decode(&value) != nil || value != 7 {
+			t.Errorf("got %v, want callback with payload 07", p)
+		}
+	case <-time.After(time.Second):
+		t.Error("callback was not delivered")
+	}
+}
+
+func TestWaitSent(t *testing.T) {
+	m := New()
+	defer m.Close()
+
+	// Packets without response are recorded after Send returned
+	p, _ := tinkerforge.NewPacket(30867, 1, false, uint8(1))
+	if _, err := m.Send(p); err != nil {
+		t.Fatal(err)
+	}
+
+	sent, err := m.WaitSent(1, time.Second)
+	if err != nil || len(sent) != 1 {
+		t.Errorf("WaitSent(1) = %v, %v, want one packet", sent, err)
+	}
+
+	start := time.Now()
+	sent, err = m.WaitSent(2, 50*time.Millisecond)
+	if err == nil || len(sent) != 1 {
+		t.Errorf("WaitSent(2) = %v, %v, want an error and one packet", sent, err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("WaitSent(2) returned after %v, before the timeout", elapsed)
+	}
+}
+
+func TestReset(t *testing.T) {
+	m := New()
+	defer m.Close()
+
+	if err := m.Respond(30867, 2, uint16(42)); err != nil {
+		t.Fatal(err)
+	}
+
+	p, _ := tinkerforge.NewPacket(30867, 2, true)
+	if _, err := m.Send(p); err != nil {
+		t.Fatal(err)
+	}
+
+	m.Reset()
+
+	if len(m.Sent()) != 0 {
+		t.Errorf("Sent() = %v after Reset, want none", m.Sent())
+	}
+	if _, err := m.Send(p); err != tinkerforge.ErrFuncNotSupported {
+		t.Errorf("Send() after Reset error = %v, want ErrFuncNotSupported", err)
+	}
+}
@@ -0,0 +1,160 @@
+// Package voltage has control routines for the Voltage Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package voltage
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// Voltage is a control structure for Voltage Bricklets
+type Voltage struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+// New creates a new voltage control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*Voltage, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &Voltage{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// GetVoltage returns the measured voltage in mV.
+func (v *Voltage) GetVoltage() (uint16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(v.uid, 1, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := v.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the voltage
+	var voltage uint16
+	if err = res.Decode(&voltage); err != nil {
+		return 0, err
+	}
+
+	return voltage, nil
+}
+
+// GetAnalogValue returns the raw value of the analog-digital converter (0 to 4095).
+func (v *Voltage) GetAnalogValue() (uint16, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(v.uid, 2, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := v.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the value
+	var value uint16
+	if err = res.Decode(&value); err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+// SetVoltageCallbackPeriod sets the period in ms with which the voltage callback is triggered.
+// The callback is only triggered if the voltage changed. A value of 0 turns the callback off.
+func (v *Voltage) SetVoltageCallbackPeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(v.uid, 3, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = v.t.Send(p)
+	return err
+}
+
+// SetVoltageCallbackThreshold sets the threshold for the voltage reached callback.
+// 'option' is one of the helpers.Threshold* constants.
+func (v *Voltage) SetVoltageCallbackThreshold(option byte, min, max uint16) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(v.uid, 7, false, option, min, max)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = v.t.Send(p)
+	return err
+}
+
+// SetAnalogValueCallbackThreshold sets the threshold for the analog value reached callback.
+// 'option' is one of the helpers.Threshold* constants.
+func (v *Voltage) SetAnalogValueCallbackThreshold(option byte, min, max uint16) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(v.uid, 9, false, option, min, max)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = v.t.Send(p)
+	return err
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (v *Voltage) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(v.t, v.uid)
+	return i, err
+}
+
+type voltageHandler func(uint16)
+
+func (f voltageHandler) Handle(p *tinkerforge.Packet) {
+
+	var voltage uint16
+
+	if p.Decode(&voltage) != nil {
+		return
+	}
+	f(voltage)
+
+}
+
+// CallbackVoltage is a convenience function for registering
+// a handler to be called periodically with the voltage.
+// The period is set with SetVoltageCallbackPeriod.
+func (v *Voltage) CallbackVoltage(handler func(uint16)) {
+
+	if handler == nil {
+		v.callbacks.Set(v.t, v.uid, 13, nil)
+	} else {
+		v.callbacks.Set(v.t, v.uid, 13, voltageHandler(handler))
+	}
+
+}
+
+// CallbackVoltageReached is a convenience function for registering
+// a handler to be called when the threshold set by SetVoltageCallbackThreshold is reached.
+func (v *Voltage) CallbackVoltageReached(handler func(uint16)) {
+
+	if handler == nil {
+		v.callbacks.Set(v.t, v.uid, 15, nil)
+	} else {
+		v.callbacks.Set(v.t, v.uid, 15, voltageHandler(handler))
+	}
+
+}
@@ -0,0 +1,95 @@
+package voltage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/noxer/tinkerforge/helpers"
+	"github.com/noxer/tinkerforge/tinkerforgetest"
+)
+
+func TestWire(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	v, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.Expect(t, v.uid, []tinkerforgetest.Expectation{
+		{Name: "SetVoltageCallbackPeriod", Call: func() error { return v.SetVoltageCallbackPeriod(1000) }, FuncID: 3, Payload: []byte{0xe8, 0x03, 0, 0}},
+		{Name: "SetVoltageCallbackThreshold", Call: func() error { return v.SetVoltageCallbackThreshold(helpers.ThresholdGreater, 5000, 0) }, FuncID: 7, Payload: []byte{'>', 0x88, 0x13, 0, 0}},
+		{Name: "SetAnalogValueCallbackThreshold", Call: func() error { return v.SetAnalogValueCallbackThreshold(helpers.ThresholdInside, 100, 4000) }, FuncID: 9, Payload: []byte{'i', 0x64, 0, 0xa0, 0x0f}},
+	})
+}
+
+func TestGetters(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	v, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		call   func() (uint16, error)
+		funcID uint8
+	}{
+		{"GetVoltage", v.GetVoltage, 1},
+		{"GetAnalogValue", v.GetAnalogValue, 2},
+	}
+
+	for _, test := range tests {
+		if err := m.Respond(v.uid, test.funcID, uint16(12345)); err != nil {
+			t.Fatal(err)
+		}
+
+		value, err := test.call()
+		if err != nil || value != 12345 {
+			t.Errorf("%s() = %d, %v, want 12345", test.name, value, err)
+		}
+	}
+}
+
+func TestCallbacks(t *testing.T) {
+	m := tinkerforgetest.New()
+	defer m.Close()
+
+	v, err := New(m, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	voltage := make(chan uint16, 1)
+	reached := make(chan uint16, 1)
+	v.CallbackVoltage(func(value uint16) { voltage <- value })
+	v.CallbackVoltageReached(func(value uint16) { reached <- value })
+
+	if err := m.Fire(v.uid, 13, uint16(3300)); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Fire(v.uid, 15, uint16(5100)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		name string
+		got  chan uint16
+		want uint16
+	}{
+		{"voltage", voltage, 3300},
+		{"voltage reached", reached, 5100},
+	} {
+		select {
+		case value := <-c.got:
+			if value != c.want {
+				t.Errorf("%s callback got %d, want %d", c.name, value, c.want)
+			}
+		case <-time.After(time.Second):
+			t.Errorf("%s callback was not called", c.name)
+		}
+	}
+}
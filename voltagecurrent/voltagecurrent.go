@@ -0,0 +1,224 @@
+// Package voltagecurrent has control routines for the Voltage/Current Bricklet
+// Author: Tim Scheuermann (https://github.com/noxer)
+package voltagecurrent
+
+import (
+	"github.com/noxer/tinkerforge"
+	"github.com/noxer/tinkerforge/helpers"
+)
+
+// VoltageCurrent is a control structure for Voltage/Current Bricklets
+type VoltageCurrent struct {
+	t         tinkerforge.Tinkerforge
+	uid       uint32
+	callbacks helpers.Callbacks
+}
+
+// New creates a new voltage/current control for the bricklet with 'uid'.
+func New(t tinkerforge.Tinkerforge, uid string) (*VoltageCurrent, error) {
+	readUID, err := helpers.Base58ToU32(uid)
+	if err != nil {
+		return nil, err
+	}
+	return &VoltageCurrent{
+		t:   t,
+		uid: readUID,
+	}, nil
+}
+
+// GetCurrent returns the measured current in mA.
+func (v *VoltageCurrent) GetCurrent() (int32, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(v.uid, 1, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := v.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the current
+	var current int32
+	if err = res.Decode(&current); err != nil {
+		return 0, err
+	}
+
+	return current, nil
+}
+
+// GetVoltage returns the measured voltage in mV.
+func (v *VoltageCurrent) GetVoltage() (int32, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(v.uid, 2, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := v.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the voltage
+	var voltage int32
+	if err = res.Decode(&voltage); err != nil {
+		return 0, err
+	}
+
+	return voltage, nil
+}
+
+// GetPower returns the measured power in mW.
+func (v *VoltageCurrent) GetPower() (int32, error) {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(v.uid, 3, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the packet
+	res, err := v.t.Send(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decode the power
+	var power int32
+	if err = res.Decode(&power); err != nil {
+		return 0, err
+	}
+
+	return power, nil
+}
+
+// SetConfiguration sets the number of averaged samples and the conversion times of voltage and current.
+// See the Tinkerforge documentation for the possible values.
+func (v *VoltageCurrent) SetConfiguration(averaging, voltageConversionTime, currentConversionTime uint8) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(v.uid, 4, false, averaging, voltageConversionTime, currentConversionTime)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = v.t.Send(p)
+	return err
+}
+
+// SetCalibration sets the factor (gainMultiplier / gainDivisor) the measured current is multiplied with.
+func (v *VoltageCurrent) SetCalibration(gainMultiplier, gainDivisor uint16) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(v.uid, 6, false, gainMultiplier, gainDivisor)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = v.t.Send(p)
+	return err
+}
+
+// SetCurrentCallbackPeriod sets the period in ms with which the current callback is triggered.
+// The callback is only triggered if the current changed. A value of 0 turns the callback off.
+func (v *VoltageCurrent) SetCurrentCallbackPeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(v.uid, 8, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = v.t.Send(p)
+	return err
+}
+
+// SetVoltageCallbackPeriod sets the period in ms with which the voltage callback is triggered.
+// The callback is only triggered if the voltage changed. A value of 0 turns the callback off.
+func (v *VoltageCurrent) SetVoltageCallbackPeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(v.uid, 10, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = v.t.Send(p)
+	return err
+}
+
+// SetPowerCallbackPeriod sets the period in ms with which the power callback is triggered.
+// The callback is only triggered if the power changed. A value of 0 turns the callback off.
+func (v *VoltageCurrent) SetPowerCallbackPeriod(period uint32) error {
+	// Create a new tinkerforge packet
+	p, err := tinkerforge.NewPacket(v.uid, 12, false, period)
+	if err != nil {
+		return err
+	}
+
+	// Send the packet
+	_, err = v.t.Send(p)
+	return err
+}
+
+// GetIdentity returns the position information of the bricklet and its identifier.
+func (v *VoltageCurrent) GetIdentity() (*helpers.BrickletIdentity, error) {
+	// Call the helper function for getting the identity
+	i, err := helpers.GetIdentity(v.t, v.uid)
+	return i, err
+}
+
+type valueHandler func(int32)
+
+func (f valueHandler) Handle(p *tinkerforge.Packet) {
+
+	var value int32
+
+	if p.Decode(&value) != nil {
+		return
+	}
+	f(value)
+
+}
+
+// CallbackCurrent is a convenience function for registering
+// a handler to be called periodically with the current.
+// The period is set with SetCurrentCallbackPeriod.
+func (v *VoltageCurrent) CallbackCurrent(handler func(int32)) {
+
+	if handler == nil {
+		v.callbacks.Set(v.t, v.uid, 22, nil)
+	} else {
+		v.callbacks.Set(v.t, v.uid, 22, valueHandler(handler))
+	}
+
+}
+
+// CallbackVoltage is a convenience function for registering
+// a handler to be called periodically with the voltage.
+// The period is set with SetVoltageCallbackPeriod.
+func (v *VoltageCurrent) CallbackVoltage(handler func(int32)) {
+
+	if handler == nil {
+		v.callbacks.Set(v.t, v.uid, 23, nil)
+	} else {
+		v.callbacks.Set(v.t, v.uid, 23, valueHandler(handler))
+	}
+
+}
+
+// CallbackPower is a convenience function for registering
+// a handler to be called periodically with the power.
+// The period is set with SetPowerCallbackPeriod.
+func (v *VoltageCurrent) CallbackPower(handler func(int32)) {
+
+	if handler == nil {
+		v.callbacks.Set(v.t, v.uid, 24, nil)
+	} else {
+		v.callbacks.Set(v.t, v.uid, 24, valueHandler(handler))
+	}
+
+}